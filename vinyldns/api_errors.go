@@ -0,0 +1,170 @@
+/*
+Copyright 2018 Comcast Cable Communications Management, LLC
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vinyldns
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/vinyldns/go-vinyldns/vinyldns"
+)
+
+// changeFailedError is returned when VinylDNS reports that a zone or record
+// set change it accepted has failed.
+type changeFailedError struct {
+	// Kind is "zone" or "record set".
+	Kind          string
+	ChangeID      string
+	ChangeType    string
+	SystemMessage string
+
+	// Identity lines describing what was being changed, such as
+	// "Zone: example.com. (zone-id)".
+	Identity []string
+}
+
+func (e *changeFailedError) Error() string {
+	return fmt.Sprintf("%s %s change %s failed: %s", e.Kind, strings.ToLower(e.ChangeType), e.ChangeID, e.reason())
+}
+
+func (e *changeFailedError) reason() string {
+	if e.SystemMessage == "" {
+		return "VinylDNS did not report a reason"
+	}
+
+	return e.SystemMessage
+}
+
+func (e *changeFailedError) diagnostic() diag.Diagnostic {
+	detail := []string{
+		e.reason(),
+		"",
+		"Change ID:   " + e.ChangeID,
+		"Change type: " + e.ChangeType,
+	}
+	detail = append(detail, e.Identity...)
+
+	return diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("VinylDNS %s change failed", e.Kind),
+		Detail:   strings.Join(detail, "\n"),
+	}
+}
+
+func recordSetChangeFailedError(rsc *recordSetChange) *changeFailedError {
+	zoneID := rsc.Zone.ID
+	if zoneID == "" {
+		zoneID = rsc.RecordSet.ZoneID
+	}
+
+	return &changeFailedError{
+		Kind:          "record set",
+		ChangeID:      rsc.ID,
+		ChangeType:    rsc.ChangeType,
+		SystemMessage: rsc.SystemMessage,
+		Identity: []string{
+			fmt.Sprintf("Record set:  %s (%s), ID %s", rsc.RecordSet.Name, rsc.RecordSet.Type, rsc.RecordSet.ID),
+			fmt.Sprintf("Zone:        %s (%s)", rsc.Zone.Name, zoneID),
+		},
+	}
+}
+
+func zoneChangeFailedError(zc vinyldns.ZoneChange) *changeFailedError {
+	return &changeFailedError{
+		Kind:          "zone",
+		ChangeID:      zc.ID,
+		ChangeType:    zc.ChangeType,
+		SystemMessage: zc.SystemMessage,
+		Identity: []string{
+			fmt.Sprintf("Zone:        %s (%s)", zc.Zone.Name, zc.Zone.ID),
+		},
+	}
+}
+
+// apiErrorHints explain the VinylDNS API errors that usually need the user
+// to change something.
+var apiErrorHints = map[int]struct {
+	Summary string
+	Hint    string
+}{
+	http.StatusBadRequest: {
+		Summary: "VinylDNS rejected the request as invalid",
+		Hint:    "Check the resource's arguments against the messages above.",
+	},
+	http.StatusForbidden: {
+		Summary: "VinylDNS denied access",
+		Hint: "The user the provider's access_key belongs to must be a member of the zone's admin group, " +
+			"the record set's owner group, or be granted access by a zone ACL rule.",
+	},
+	http.StatusConflict: {
+		Summary: "VinylDNS reported a conflict",
+		Hint: "The object may already exist or have been changed outside of Terraform. " +
+			"Import an existing object with terraform import, or run terraform refresh and try again.",
+	},
+	http.StatusUnprocessableEntity: {
+		Summary: "VinylDNS could not process the request",
+		Hint:    "The request is valid but conflicts with the zone's current records or configuration.",
+	},
+}
+
+// errorDiagnostics converts err, returned while performing action (such as
+// "creating record set (www)"), into diagnostics. Failed changes and the
+// common VinylDNS API errors get a summary and actionable detail; other
+// errors are reported as "error <action>: <err>".
+func errorDiagnostics(action string, err error) diag.Diagnostics {
+	var changeErr *changeFailedError
+	if errors.As(err, &changeErr) {
+		return diag.Diagnostics{changeErr.diagnostic()}
+	}
+
+	var vErr *vinyldns.Error
+	if errors.As(err, &vErr) {
+		if h, ok := apiErrorHints[vErr.ResponseCode]; ok {
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("error %s: %s", action, h.Summary),
+				Detail: fmt.Sprintf("%s\n\n%s\n\n%s %s returned %d.",
+					strings.Join(apiErrorMessages(vErr.ResponseBody), "\n"), h.Hint,
+					vErr.RequestMethod, vErr.RequestURL, vErr.ResponseCode),
+			}}
+		}
+	}
+
+	return diag.Errorf("error %s: %s", action, err)
+}
+
+// apiErrorMessages extracts the messages from a VinylDNS error response
+// body, which is either plain text or a JSON object with an errors list.
+func apiErrorMessages(body string) []string {
+	var structured struct {
+		Errors []string `json:"errors"`
+	}
+	if err := json.Unmarshal([]byte(body), &structured); err == nil && len(structured.Errors) > 0 {
+		return structured.Errors
+	}
+
+	var message string
+	if err := json.Unmarshal([]byte(body), &message); err == nil && message != "" {
+		return []string{message}
+	}
+
+	if body = strings.TrimSpace(body); body != "" {
+		return []string{body}
+	}
+
+	return []string{"VinylDNS did not report a reason"}
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2018 Comcast Cable Communications Management, LLC
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vinyldns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vinyldns/go-vinyldns/vinyldns"
+)
+
+func TestErrorDiagnostics(t *testing.T) {
+	apiError := func(code int, body string) error {
+		return &vinyldns.Error{
+			RequestURL:    "https://vinyldns.example.com/zones/zone-id/recordsets",
+			RequestMethod: http.MethodPost,
+			ResponseCode:  code,
+			ResponseBody:  body,
+		}
+	}
+
+	cases := map[string]struct {
+		Err           error
+		ExpectSummary string
+		ExpectDetail  []string
+	}{
+		"400 with a list of errors": {
+			Err:           apiError(http.StatusBadRequest, `{"errors":["Missing RecordSet.ttl","Invalid record type"]}`),
+			ExpectSummary: "error creating recordset (www): VinylDNS rejected the request as invalid",
+			ExpectDetail:  []string{"Missing RecordSet.ttl\nInvalid record type", "POST https://vinyldns.example.com/zones/zone-id/recordsets returned 400."},
+		},
+		"403 plain text": {
+			Err:           apiError(http.StatusForbidden, "User ok does not have access to create www.example.com."),
+			ExpectSummary: "error creating recordset (www): VinylDNS denied access",
+			ExpectDetail:  []string{"User ok does not have access to create www.example.com.", "zone ACL rule"},
+		},
+		"409 JSON string": {
+			Err:           apiError(http.StatusConflict, `"RecordSet with name www and type A already exists in zone example.com."`),
+			ExpectSummary: "error creating recordset (www): VinylDNS reported a conflict",
+			ExpectDetail:  []string{"RecordSet with name www and type A already exists in zone example.com.", "terraform import"},
+		},
+		"422": {
+			Err:           apiError(http.StatusUnprocessableEntity, "CNAME conflicts with existing A record"),
+			ExpectSummary: "error creating recordset (www): VinylDNS could not process the request",
+			ExpectDetail:  []string{"CNAME conflicts with existing A record"},
+		},
+		"422 without a body": {
+			Err:           apiError(http.StatusUnprocessableEntity, ""),
+			ExpectSummary: "error creating recordset (www): VinylDNS could not process the request",
+			ExpectDetail:  []string{"VinylDNS did not report a reason"},
+		},
+		"unmapped API error": {
+			Err:           apiError(http.StatusInternalServerError, "boom"),
+			ExpectSummary: "error creating recordset (www): " + apiError(http.StatusInternalServerError, "boom").Error(),
+		},
+		"other error": {
+			Err:           errors.New("connection refused"),
+			ExpectSummary: "error creating recordset (www): connection refused",
+		},
+		"failed change": {
+			Err: &changeFailedError{
+				Kind:          "record set",
+				ChangeID:      "change-id",
+				ChangeType:    "Create",
+				SystemMessage: "Failed validating update to DNS for change change-id:www: Refused",
+				Identity:      []string{"Zone:        example.com. (zone-id)"},
+			},
+			ExpectSummary: "VinylDNS record set change failed",
+			ExpectDetail: []string{
+				"Failed validating update to DNS for change change-id:www: Refused",
+				"Change ID:   change-id",
+				"Change type: Create",
+				"Zone:        example.com. (zone-id)",
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			diags := errorDiagnostics("creating recordset (www)", tc.Err)
+			if len(diags) != 1 || !diags.HasError() {
+				t.Fatalf("expected a single error diagnostic, got %#v", diags)
+			}
+			if diags[0].Summary != tc.ExpectSummary {
+				t.Fatalf("expected summary %q, got %q", tc.ExpectSummary, diags[0].Summary)
+			}
+			for _, e := range tc.ExpectDetail {
+				if !strings.Contains(diags[0].Detail, e) {
+					t.Fatalf("expected detail to contain %q, got %q", e, diags[0].Detail)
+				}
+			}
+		})
+	}
+}
+
+func testAPIServer(t *testing.T, path, body string) *providerMeta {
+	return testAPIServerRoutes(t, map[string]string{path: body})
+}
+
+// testForbidden is a testAPIServerRoutes body that responds with a 403.
+const testForbidden = "forbidden"
+
+// testAPIServerRoutes serves each body in routes at its path, or a 404 for
+// an empty body, and fails the test on a request to any other path.
+func testAPIServerRoutes(t *testing.T, routes map[string]string) *providerMeta {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := routes[r.URL.Path]
+		if !ok {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		if body == "" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, "The requested resource could not be found")
+			return
+		}
+		if body == testForbidden {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, "User does not have access")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(server.Close)
+
+	return &providerMeta{
+		client: vinyldns.NewClient(vinyldns.ClientConfiguration{
+			AccessKey: "accessKey",
+			SecretKey: "secretKey",
+			Host:      server.URL,
+		}),
+	}
+}
+
+func TestRecordSetStateRefreshFuncFailed(t *testing.T) {
+	meta := testAPIServer(t, "/zones/zone-id/recordsets/rs-id/changes/change-id", `{
+		"zone": {"id": "zone-id", "name": "example.com."},
+		"recordSet": {"id": "rs-id", "zoneId": "zone-id", "name": "www", "type": "A"},
+		"changeType": "Update",
+		"status": "Failed",
+		"systemMessage": "Refused by the DNS backend",
+		"id": "change-id"
+	}`)
+
+	d := schema.TestResourceDataRaw(t, resourceVinylDNSRecordSet().Schema, map[string]interface{}{"zone_id": "zone-id"})
+	d.SetId("zone-id:rs-id")
+
+	_, state, err := recordSetStateRefreshFunc(context.Background(), d, meta, "change-id")()
+	if state != "Failed" {
+		t.Fatalf("expected state Failed, got %s", state)
+	}
+
+	var changeErr *changeFailedError
+	if !errors.As(err, &changeErr) {
+		t.Fatalf("expected a *changeFailedError, got %#v", err)
+	}
+
+	detail := changeErr.diagnostic().Detail
+	for _, e := range []string{
+		"Refused by the DNS backend",
+		"Change ID:   change-id",
+		"Change type: Update",
+		"Record set:  www (A), ID rs-id",
+		"Zone:        example.com. (zone-id)",
+	} {
+		if !strings.Contains(detail, e) {
+			t.Fatalf("expected detail to contain %q, got %q", e, detail)
+		}
+	}
+}
+
+func TestZoneStateRefreshFuncFailed(t *testing.T) {
+	meta := testAPIServer(t, "/zones/zone-id/changes", `{"zoneChanges": [{
+		"zone": {"id": "zone-id", "name": "example.com."},
+		"changeType": "Update",
+		"status": "Failed",
+		"systemMessage": "Unable to connect to the zone's primary server",
+		"id": "change-id"
+	}]}`)
+
+	d := schema.TestResourceDataRaw(t, resourceVinylDNSZone().Schema, map[string]interface{}{"name": "example.com."})
+	d.SetId("zone-id")
+
+	_, _, err := zoneStateRefreshFunc(d, meta, "change-id")()
+
+	var changeErr *changeFailedError
+	if !errors.As(err, &changeErr) {
+		t.Fatalf("expected a *changeFailedError, got %#v", err)
+	}
+
+	expected := "zone update change change-id failed: Unable to connect to the zone's primary server"
+	if err.Error() != expected {
+		t.Fatalf("expected %q, got %q", expected, err.Error())
+	}
+	if !strings.Contains(changeErr.diagnostic().Detail, "Zone:        example.com. (zone-id)") {
+		t.Fatalf("expected detail to identify the zone, got %q", changeErr.diagnostic().Detail)
+	}
+}
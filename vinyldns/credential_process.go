@@ -0,0 +1,222 @@
+/*
+Copyright 2018 Comcast Cable Communications Management, LLC
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vinyldns
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+// credentialProcessExpiryWindow is how long before their expiry credentials
+// from a credential_process are refreshed, so that a request signed just
+// before the keys expire is not rejected.
+const credentialProcessExpiryWindow = time.Minute
+
+// credentialProcessOutput is the JSON a credential_process prints to stdout.
+type credentialProcessOutput struct {
+	AccessKey  string `json:"access_key"`
+	SecretKey  string `json:"secret_key"`
+	Expiration string `json:"expiration,omitempty"`
+}
+
+// processCredentials are the keys returned by a credential_process. A zero
+// Expiration means they do not expire.
+type processCredentials struct {
+	AccessKey  string
+	SecretKey  string
+	Expiration time.Time
+}
+
+func (c processCredentials) expired(now time.Time) bool {
+	return !c.Expiration.IsZero() && !now.Before(c.Expiration.Add(-credentialProcessExpiryWindow))
+}
+
+// credentialProcess runs an external command to fetch short-lived VinylDNS
+// keys, caching them until they are about to expire.
+type credentialProcess struct {
+	command string
+
+	// run and now are only overridden by tests.
+	run func(ctx context.Context, command string) ([]byte, error)
+	now func() time.Time
+
+	mu      sync.Mutex
+	current *processCredentials
+}
+
+func newCredentialProcess(command string) *credentialProcess {
+	return &credentialProcess{
+		command: command,
+		run:     runCredentialProcess,
+		now:     time.Now,
+	}
+}
+
+// Retrieve returns the cached credentials, running the command again if
+// there are none yet or they are about to expire.
+func (p *credentialProcess) Retrieve(ctx context.Context) (processCredentials, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.current != nil && !p.current.expired(p.now()) {
+		return *p.current, nil
+	}
+
+	log.Printf("[INFO] Fetching VinylDNS credentials from credential_process")
+
+	out, err := p.run(ctx, p.command)
+	if err != nil {
+		return processCredentials{}, fmt.Errorf("error running credential_process: %s", err)
+	}
+
+	creds, err := parseCredentialProcessOutput(out)
+	if err != nil {
+		return processCredentials{}, fmt.Errorf("error reading credential_process output: %s", err)
+	}
+
+	if creds.expired(p.now()) {
+		return processCredentials{}, fmt.Errorf("credential_process returned credentials that expire at %s", creds.Expiration.Format(time.RFC3339))
+	}
+
+	p.current = &creds
+
+	return creds, nil
+}
+
+func parseCredentialProcessOutput(out []byte) (processCredentials, error) {
+	var o credentialProcessOutput
+	if err := json.Unmarshal(out, &o); err != nil {
+		return processCredentials{}, err
+	}
+
+	if o.AccessKey == "" || o.SecretKey == "" {
+		return processCredentials{}, errors.New("access_key and secret_key are required")
+	}
+
+	creds := processCredentials{
+		AccessKey: o.AccessKey,
+		SecretKey: o.SecretKey,
+	}
+
+	if o.Expiration != "" {
+		expiration, err := time.Parse(time.RFC3339, o.Expiration)
+		if err != nil {
+			return processCredentials{}, fmt.Errorf("expiration must be an RFC 3339 timestamp: %s", err)
+		}
+		creds.Expiration = expiration
+	}
+
+	return creds, nil
+}
+
+// runCredentialProcess runs command through the platform's shell and returns
+// its stdout.
+func runCredentialProcess(ctx context.Context, command string) ([]byte, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd.exe", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s: %s", err, msg)
+		}
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// credentialProcessTransport signs each VinylDNS API request with the
+// current credentials from a credential_process, replacing the signature
+// made by the go-vinyldns client with the keys it was configured with.
+// Signing every attempt here also means a request retried after the keys
+// were refreshed is signed with the new keys.
+type credentialProcessTransport struct {
+	next    http.RoundTripper
+	process *credentialProcess
+	signer  *v4.Signer
+}
+
+func newCredentialProcessTransport(next http.RoundTripper, process *credentialProcess) *credentialProcessTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &credentialProcessTransport{
+		next:    next,
+		process: process,
+		signer:  v4.NewSigner(),
+	}
+}
+
+func (t *credentialProcessTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	creds, err := t.process.Retrieve(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	signed := req.Clone(req.Context())
+
+	h := sha256.New()
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return nil, errors.New("cannot sign a VinylDNS API request without GetBody")
+		}
+
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		_, err = io.Copy(h, body)
+		body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	signed.Header.Del("Authorization")
+	signed.Header.Del("X-Amz-Date")
+
+	err = t.signer.SignHTTP(req.Context(), aws.Credentials{
+		AccessKeyID:     creds.AccessKey,
+		SecretAccessKey: creds.SecretKey,
+	}, signed, hex.EncodeToString(h.Sum(nil)), "VinylDNS", "us-east-1", t.process.now())
+	if err != nil {
+		return nil, fmt.Errorf("error signing VinylDNS API request: %s", err)
+	}
+
+	return t.next.RoundTrip(signed)
+}
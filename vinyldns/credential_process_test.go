@@ -0,0 +1,205 @@
+/*
+Copyright 2018 Comcast Cable Communications Management, LLC
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vinyldns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vinyldns/go-vinyldns/vinyldns"
+)
+
+// fakeCredentialProcess returns a credentialProcess whose command prints the
+// next of outputs each time it is run, and a clock the test can advance.
+func fakeCredentialProcess(outputs ...string) (*credentialProcess, *int, *time.Time) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	runs := 0
+
+	p := newCredentialProcess("fake")
+	p.now = func() time.Time { return now }
+	p.run = func(context.Context, string) ([]byte, error) {
+		if runs >= len(outputs) {
+			return nil, errors.New("no more credentials")
+		}
+		out := outputs[runs]
+		runs++
+		return []byte(out), nil
+	}
+
+	return p, &runs, &now
+}
+
+func TestParseCredentialProcessOutput(t *testing.T) {
+	cases := map[string]struct {
+		Output      string
+		Expected    processCredentials
+		ExpectError bool
+	}{
+		"without expiration": {
+			Output:   `{"access_key":"ak","secret_key":"sk"}`,
+			Expected: processCredentials{AccessKey: "ak", SecretKey: "sk"},
+		},
+		"with expiration": {
+			Output:   `{"access_key":"ak","secret_key":"sk","expiration":"2020-01-01T01:00:00Z"}`,
+			Expected: processCredentials{AccessKey: "ak", SecretKey: "sk", Expiration: time.Date(2020, 1, 1, 1, 0, 0, 0, time.UTC)},
+		},
+		"not JSON": {
+			Output:      "ak sk",
+			ExpectError: true,
+		},
+		"missing secret_key": {
+			Output:      `{"access_key":"ak"}`,
+			ExpectError: true,
+		},
+		"invalid expiration": {
+			Output:      `{"access_key":"ak","secret_key":"sk","expiration":"in an hour"}`,
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			creds, err := parseCredentialProcessOutput([]byte(tc.Output))
+			if tc.ExpectError {
+				if err == nil {
+					t.Fatalf("expected an error but one was not raised")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect an error but one was raised: %s", err)
+			}
+			if !creds.Expiration.Equal(tc.Expected.Expiration) || creds.AccessKey != tc.Expected.AccessKey || creds.SecretKey != tc.Expected.SecretKey {
+				t.Fatalf("expected %#v, got %#v", tc.Expected, creds)
+			}
+		})
+	}
+}
+
+func TestCredentialProcessRefresh(t *testing.T) {
+	p, runs, now := fakeCredentialProcess(
+		`{"access_key":"first","secret_key":"sk","expiration":"2020-01-01T00:15:00Z"}`,
+		`{"access_key":"second","secret_key":"sk"}`,
+	)
+
+	steps := []struct {
+		Advance      time.Duration
+		ExpectKey    string
+		ExpectedRuns int
+	}{
+		{Advance: 0, ExpectKey: "first", ExpectedRuns: 1},
+		{Advance: 10 * time.Minute, ExpectKey: "first", ExpectedRuns: 1},
+		// within credentialProcessExpiryWindow of the expiration
+		{Advance: 4 * time.Minute, ExpectKey: "second", ExpectedRuns: 2},
+		// credentials without an expiration are kept
+		{Advance: 24 * time.Hour, ExpectKey: "second", ExpectedRuns: 2},
+	}
+
+	for i, s := range steps {
+		*now = now.Add(s.Advance)
+
+		creds, err := p.Retrieve(context.Background())
+		if err != nil {
+			t.Fatalf("step %d: did not expect an error but one was raised: %s", i, err)
+		}
+		if creds.AccessKey != s.ExpectKey {
+			t.Fatalf("step %d: expected access key %s, got %s", i, s.ExpectKey, creds.AccessKey)
+		}
+		if *runs != s.ExpectedRuns {
+			t.Fatalf("step %d: expected %d runs, got %d", i, s.ExpectedRuns, *runs)
+		}
+	}
+}
+
+func TestCredentialProcessExpiredOutput(t *testing.T) {
+	p, _, _ := fakeCredentialProcess(`{"access_key":"ak","secret_key":"sk","expiration":"2019-12-31T23:59:30Z"}`)
+
+	if _, err := p.Retrieve(context.Background()); err == nil {
+		t.Fatalf("expected an error but one was not raised")
+	}
+}
+
+func TestRunCredentialProcess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+
+	p := newCredentialProcess(`printf '{"access_key":"%s","secret_key":"sk"}' shell`)
+	creds, err := p.Retrieve(context.Background())
+	if err != nil {
+		t.Fatalf("did not expect an error but one was raised: %s", err)
+	}
+	if creds.AccessKey != "shell" {
+		t.Fatalf("expected access key shell, got %s", creds.AccessKey)
+	}
+
+	p = newCredentialProcess("echo 'not logged in' >&2; exit 1")
+	_, err = p.Retrieve(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "not logged in") {
+		t.Fatalf("expected an error including the command's stderr, got %v", err)
+	}
+}
+
+func TestCredentialProcessTransport(t *testing.T) {
+	var mu sync.Mutex
+	var signedWith []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		signedWith = append(signedWith, r.Header.Get("Authorization"))
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id":"group-id","name":"group"}`)
+	}))
+	defer server.Close()
+
+	p, _, now := fakeCredentialProcess(
+		`{"access_key":"first","secret_key":"sk","expiration":"2020-01-01T00:15:00Z"}`,
+		`{"access_key":"second","secret_key":"sk","expiration":"2020-01-01T00:30:00Z"}`,
+	)
+
+	client := vinyldns.NewClient(vinyldns.ClientConfiguration{
+		AccessKey: "static",
+		SecretKey: "static",
+		Host:      server.URL,
+	})
+	client.HTTPClient.Transport = newCredentialProcessTransport(nil, p)
+
+	if _, err := client.GroupCreate(&vinyldns.Group{Name: "group"}); err != nil {
+		t.Fatalf("did not expect an error but one was raised: %s", err)
+	}
+
+	*now = now.Add(20 * time.Minute)
+
+	if _, err := client.GroupCreate(&vinyldns.Group{Name: "group"}); err != nil {
+		t.Fatalf("did not expect an error but one was raised: %s", err)
+	}
+
+	expected := []string{"Credential=first/", "Credential=second/"}
+	if len(signedWith) != len(expected) {
+		t.Fatalf("expected %d requests, got %d", len(expected), len(signedWith))
+	}
+	for i, e := range expected {
+		if !strings.Contains(signedWith[i], e) {
+			t.Fatalf("expected request %d to be signed with %q, got %q", i, e, signedWith[i])
+		}
+	}
+}
@@ -0,0 +1,319 @@
+/*
+Copyright 2018 Comcast Cable Communications Management, LLC
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vinyldns
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vinyldns/go-vinyldns/vinyldns"
+)
+
+const (
+	defaultProfile               = "default"
+	defaultSharedCredentialsFile = "~/.vinyldns/credentials"
+)
+
+// credentials are the values needed to sign requests to a VinylDNS API.
+// Either both keys or CredentialProcess, the command that fetches them, are
+// set.
+type credentials struct {
+	Host              string
+	AccessKey         string
+	SecretKey         string
+	CredentialProcess string
+}
+
+// credentialsConfig holds the provider arguments that determine which
+// credentials are used.
+type credentialsConfig struct {
+	// Host, AccessKey and SecretKey are set from the provider block or,
+	// failing that, from the VINYLDNS_* environment variables.
+	Host              string
+	AccessKey         string
+	SecretKey         string
+	CredentialProcess string
+
+	Profile               string
+	SharedCredentialsFile string
+}
+
+func credentialsConfigFromResourceData(d *schema.ResourceData) credentialsConfig {
+	return credentialsConfig{
+		Host:                  d.Get("host").(string),
+		AccessKey:             d.Get("access_key").(string),
+		SecretKey:             d.Get("secret_key").(string),
+		CredentialProcess:     d.Get("credential_process").(string),
+		Profile:               d.Get("profile").(string),
+		SharedCredentialsFile: d.Get("shared_credentials_file").(string),
+	}
+}
+
+// resolveCredentials determines the credentials to use. Each value is taken
+// from, in order of precedence:
+//
+//  1. the provider block
+//  2. the VINYLDNS_HOST, VINYLDNS_ACCESS_KEY, VINYLDNS_SECRET_KEY and
+//     VINYLDNS_CREDENTIAL_PROCESS environment variables
+//  3. the profile in the shared credentials file
+//
+// The keys and credential_process are alternatives: whichever is set first
+// in that order is used, and setting both at the same level is an error.
+//
+// The profile defaults to "default", and the shared credentials file to
+// ~/.vinyldns/credentials. A missing file or profile is only an error when
+// it was asked for explicitly.
+func resolveCredentials(c credentialsConfig) (credentials, error) {
+	creds := credentials{
+		Host:              c.Host,
+		AccessKey:         c.AccessKey,
+		SecretKey:         c.SecretKey,
+		CredentialProcess: c.CredentialProcess,
+	}
+
+	if err := checkCredentialProcessConflict(creds); err != nil {
+		return creds, err
+	}
+
+	keysSet := creds.AccessKey != "" || creds.SecretKey != "" || creds.CredentialProcess != ""
+
+	if creds.Host == "" || !keysSet {
+		profile, err := sharedCredentialsProfile(c)
+		if err != nil {
+			return creds, err
+		}
+
+		if creds.Host == "" {
+			creds.Host = profile.Host
+		}
+
+		// the keys are only useful as a pair, so a partially configured
+		// pair is never completed from the file
+		if !keysSet {
+			if err := checkCredentialProcessConflict(profile); err != nil {
+				return creds, fmt.Errorf("in shared credentials file: %s", err)
+			}
+
+			creds.AccessKey = profile.AccessKey
+			creds.SecretKey = profile.SecretKey
+			creds.CredentialProcess = profile.CredentialProcess
+		}
+	}
+
+	return creds, nil
+}
+
+func checkCredentialProcessConflict(c credentials) error {
+	if c.CredentialProcess != "" && (c.AccessKey != "" || c.SecretKey != "") {
+		return errors.New("credential_process cannot be used with access_key or secret_key")
+	}
+
+	return nil
+}
+
+func sharedCredentialsProfile(c credentialsConfig) (credentials, error) {
+	explicitFile := c.SharedCredentialsFile != ""
+	explicitProfile := c.Profile != ""
+
+	path := c.SharedCredentialsFile
+	if !explicitFile {
+		path = defaultSharedCredentialsFile
+	}
+
+	profile := c.Profile
+	if !explicitProfile {
+		profile = defaultProfile
+	}
+
+	path, err := expandHome(path)
+	if err != nil {
+		return credentials{}, err
+	}
+
+	profiles, err := loadSharedCredentials(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && !explicitFile && !explicitProfile {
+			return credentials{}, nil
+		}
+
+		return credentials{}, fmt.Errorf("error reading shared credentials file %s: %s", path, err)
+	}
+
+	creds, ok := profiles[profile]
+	if !ok {
+		if !explicitProfile {
+			return credentials{}, nil
+		}
+
+		return credentials{}, fmt.Errorf("profile %q not found in shared credentials file %s", profile, path)
+	}
+
+	log.Printf("[INFO] Using profile %q from shared credentials file %s", profile, path)
+
+	return creds, nil
+}
+
+// loadSharedCredentials parses an INI style credentials file:
+//
+//	[default]
+//	host       = https://vinyldns.example.com
+//	access_key = ...
+//	secret_key = ...
+func loadSharedCredentials(path string) (map[string]credentials, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	profiles := map[string]credentials{}
+	profile := ""
+
+	scanner := bufio.NewScanner(f)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			profile = strings.TrimSpace(line[1 : len(line)-1])
+			if _, ok := profiles[profile]; !ok {
+				profiles[profile] = credentials{}
+			}
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("line %d: expected key = value", lineNo)
+		}
+		if profile == "" {
+			return nil, fmt.Errorf("line %d: %q is not within a [profile] section", lineNo, line)
+		}
+
+		key := strings.TrimSpace(parts[0])
+		value := strings.TrimSpace(parts[1])
+
+		creds := profiles[profile]
+		switch key {
+		case "host":
+			creds.Host = value
+		case "access_key":
+			creds.AccessKey = value
+		case "secret_key":
+			creds.SecretKey = value
+		case "credential_process":
+			creds.CredentialProcess = value
+		default:
+			log.Printf("[WARN] ignoring unknown key %q in shared credentials file %s", key, path)
+		}
+		profiles[profile] = creds
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return profiles, nil
+}
+
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("error expanding %s: %s", path, err)
+	}
+
+	return filepath.Join(home, path[1:]), nil
+}
+
+// validateCredentials makes a cheap authenticated request, listing the
+// groups of the user the keys belong to, so that a wrong host or bad keys
+// fail the provider configuration with an error saying which is wrong.
+func validateCredentials(client *vinyldns.Client) diag.Diagnostics {
+	if client.Host == "" {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "VinylDNS host is not set",
+			Detail:   "Set host in the provider block, through the VINYLDNS_HOST environment variable, or in a shared credentials file profile.",
+		}}
+	}
+
+	log.Printf("[INFO] Validating VinylDNS credentials against %s", client.Host)
+
+	if _, err := client.Groups(); err != nil {
+		return diag.Diagnostics{credentialsValidationDiagnostic(client.Host, err)}
+	}
+
+	return nil
+}
+
+func credentialsValidationDiagnostic(host string, err error) diag.Diagnostic {
+	var vErr *vinyldns.Error
+	if errors.As(err, &vErr) {
+		switch vErr.ResponseCode {
+		case http.StatusUnauthorized:
+			return diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "VinylDNS rejected the request signature",
+				Detail: fmt.Sprintf("The VinylDNS API at %s could not authenticate the provider (401 Unauthorized). "+
+					"Check that access_key and secret_key are correct and were issued by this VinylDNS instance.\n\n%s", host, vErr.ResponseBody),
+			}
+		case http.StatusForbidden:
+			return diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "VinylDNS credentials are not allowed to use the API",
+				Detail: fmt.Sprintf("The VinylDNS API at %s accepted the signature but refused the request (403 Forbidden). "+
+					"The account may be locked or not yet allowed to use the API.\n\n%s", host, vErr.ResponseBody),
+			}
+		default:
+			return diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unexpected response from the VinylDNS API",
+				Detail: fmt.Sprintf("Validating credentials against %s returned %d. Check that host is the URL of a VinylDNS API, "+
+					"or set skip_credentials_validation to skip this check.\n\n%s", host, vErr.ResponseCode, vErr.ResponseBody),
+			}
+		}
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to reach the VinylDNS API",
+			Detail: fmt.Sprintf("Could not connect to the VinylDNS API at %s: %s\n\n"+
+				"Check host and any proxy or TLS settings.", host, urlErr.Err),
+		}
+	}
+
+	return diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  "Unexpected response from the VinylDNS API",
+		Detail: fmt.Sprintf("The response from %s could not be read: %s\n\n"+
+			"Check that host is the URL of a VinylDNS API, or set skip_credentials_validation to skip this check.", host, err),
+	}
+}
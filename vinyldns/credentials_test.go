@@ -0,0 +1,337 @@
+/*
+Copyright 2018 Comcast Cable Communications Management, LLC
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vinyldns
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testSharedCredentials = `
+# VinylDNS credentials
+[default]
+host       = https://default.example.com
+access_key = defaultAccessKey
+secret_key = defaultSecretKey
+
+[ci]
+host       = https://ci.example.com
+access_key = ciAccessKey
+secret_key = ciSecretKey
+
+; a profile without a host
+[keys-only]
+access_key=keysOnlyAccessKey
+secret_key=keysOnlySecretKey
+
+[process]
+credential_process = vinyldns-login --print-keys
+`
+
+func testSharedCredentialsFile(t *testing.T, contents string) string {
+	path := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(path, []byte(contents), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestLoadSharedCredentials(t *testing.T) {
+	profiles, err := loadSharedCredentials(testSharedCredentialsFile(t, testSharedCredentials))
+	if err != nil {
+		t.Fatalf("did not expect an error but one was raised: %s", err)
+	}
+
+	expected := map[string]credentials{
+		"default":   {Host: "https://default.example.com", AccessKey: "defaultAccessKey", SecretKey: "defaultSecretKey"},
+		"ci":        {Host: "https://ci.example.com", AccessKey: "ciAccessKey", SecretKey: "ciSecretKey"},
+		"keys-only": {AccessKey: "keysOnlyAccessKey", SecretKey: "keysOnlySecretKey"},
+		"process":   {CredentialProcess: "vinyldns-login --print-keys"},
+	}
+	if !reflect.DeepEqual(profiles, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, profiles)
+	}
+}
+
+func TestLoadSharedCredentialsInvalid(t *testing.T) {
+	cases := map[string]string{
+		"no section": "access_key = foo\n",
+		"not a pair": "[default]\naccess_key\n",
+	}
+
+	for tn, contents := range cases {
+		t.Run(tn, func(t *testing.T) {
+			if _, err := loadSharedCredentials(testSharedCredentialsFile(t, contents)); err == nil {
+				t.Fatalf("expected an error but one was not raised")
+			}
+		})
+	}
+}
+
+func TestResolveCredentials(t *testing.T) {
+	file := testSharedCredentialsFile(t, testSharedCredentials)
+	missing := filepath.Join(t.TempDir(), "missing")
+
+	// point the default file at an empty home directory
+	t.Setenv("HOME", t.TempDir())
+
+	cases := map[string]struct {
+		Config      credentialsConfig
+		Expected    credentials
+		ExpectError bool
+	}{
+		"explicit values win over the file": {
+			Config: credentialsConfig{
+				Host:                  "https://explicit.example.com",
+				AccessKey:             "explicitAccessKey",
+				SecretKey:             "explicitSecretKey",
+				Profile:               "ci",
+				SharedCredentialsFile: file,
+			},
+			Expected: credentials{Host: "https://explicit.example.com", AccessKey: "explicitAccessKey", SecretKey: "explicitSecretKey"},
+		},
+		"default profile": {
+			Config:   credentialsConfig{SharedCredentialsFile: file},
+			Expected: credentials{Host: "https://default.example.com", AccessKey: "defaultAccessKey", SecretKey: "defaultSecretKey"},
+		},
+		"named profile": {
+			Config:   credentialsConfig{Profile: "ci", SharedCredentialsFile: file},
+			Expected: credentials{Host: "https://ci.example.com", AccessKey: "ciAccessKey", SecretKey: "ciSecretKey"},
+		},
+		"explicit host with profile keys": {
+			Config:   credentialsConfig{Host: "https://explicit.example.com", Profile: "ci", SharedCredentialsFile: file},
+			Expected: credentials{Host: "https://explicit.example.com", AccessKey: "ciAccessKey", SecretKey: "ciSecretKey"},
+		},
+		"explicit keys with profile host": {
+			Config:   credentialsConfig{AccessKey: "explicitAccessKey", SecretKey: "explicitSecretKey", Profile: "ci", SharedCredentialsFile: file},
+			Expected: credentials{Host: "https://ci.example.com", AccessKey: "explicitAccessKey", SecretKey: "explicitSecretKey"},
+		},
+		"partial keys are not completed from the file": {
+			Config:   credentialsConfig{AccessKey: "explicitAccessKey", Profile: "ci", SharedCredentialsFile: file},
+			Expected: credentials{Host: "https://ci.example.com", AccessKey: "explicitAccessKey"},
+		},
+		"credential_process from the profile": {
+			Config:   credentialsConfig{Host: "https://explicit.example.com", Profile: "process", SharedCredentialsFile: file},
+			Expected: credentials{Host: "https://explicit.example.com", CredentialProcess: "vinyldns-login --print-keys"},
+		},
+		"explicit credential_process wins over profile keys": {
+			Config:   credentialsConfig{CredentialProcess: "explicit-process", Profile: "ci", SharedCredentialsFile: file},
+			Expected: credentials{Host: "https://ci.example.com", CredentialProcess: "explicit-process"},
+		},
+		"explicit keys win over profile credential_process": {
+			Config:   credentialsConfig{AccessKey: "explicitAccessKey", SecretKey: "explicitSecretKey", Profile: "process", SharedCredentialsFile: file},
+			Expected: credentials{AccessKey: "explicitAccessKey", SecretKey: "explicitSecretKey"},
+		},
+		"credential_process with keys": {
+			Config:      credentialsConfig{AccessKey: "explicitAccessKey", CredentialProcess: "explicit-process"},
+			ExpectError: true,
+		},
+		"profile with credential_process and keys": {
+			Config:      credentialsConfig{Profile: "both", SharedCredentialsFile: testSharedCredentialsFile(t, "[both]\naccess_key = a\ncredential_process = b\n")},
+			ExpectError: true,
+		},
+		"missing default file is ignored": {
+			Config:   credentialsConfig{AccessKey: "explicitAccessKey"},
+			Expected: credentials{AccessKey: "explicitAccessKey"},
+		},
+		"missing default profile is ignored": {
+			Config:   credentialsConfig{SharedCredentialsFile: testSharedCredentialsFile(t, "[ci]\naccess_key = a\n")},
+			Expected: credentials{},
+		},
+		"missing explicit file": {
+			Config:      credentialsConfig{SharedCredentialsFile: missing},
+			ExpectError: true,
+		},
+		"missing explicit profile": {
+			Config:      credentialsConfig{Profile: "unknown", SharedCredentialsFile: file},
+			ExpectError: true,
+		},
+		"explicit profile without a file": {
+			Config:      credentialsConfig{Profile: "ci"},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			creds, err := resolveCredentials(tc.Config)
+			if tc.ExpectError {
+				if err == nil {
+					t.Fatalf("expected an error but one was not raised")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect an error but one was raised: %s", err)
+			}
+			if creds != tc.Expected {
+				t.Fatalf("expected %#v, got %#v", tc.Expected, creds)
+			}
+		})
+	}
+}
+
+func TestResolveCredentialsEnvironment(t *testing.T) {
+	file := testSharedCredentialsFile(t, testSharedCredentials)
+
+	cases := map[string]struct {
+		Env      map[string]string
+		Raw      map[string]interface{}
+		Expected credentials
+	}{
+		"environment wins over the file": {
+			Env: map[string]string{
+				"VINYLDNS_ACCESS_KEY": "envAccessKey",
+				"VINYLDNS_SECRET_KEY": "envSecretKey",
+			},
+			Raw:      map[string]interface{}{"shared_credentials_file": file},
+			Expected: credentials{Host: "https://default.example.com", AccessKey: "envAccessKey", SecretKey: "envSecretKey"},
+		},
+		"provider block wins over the environment": {
+			Env: map[string]string{
+				"VINYLDNS_HOST":       "https://env.example.com",
+				"VINYLDNS_ACCESS_KEY": "envAccessKey",
+				"VINYLDNS_SECRET_KEY": "envSecretKey",
+			},
+			Raw: map[string]interface{}{
+				"host":       "https://explicit.example.com",
+				"access_key": "explicitAccessKey",
+				"secret_key": "explicitSecretKey",
+			},
+			Expected: credentials{Host: "https://explicit.example.com", AccessKey: "explicitAccessKey", SecretKey: "explicitSecretKey"},
+		},
+		"profile and file from the environment": {
+			Env: map[string]string{
+				"VINYLDNS_PROFILE":                 "ci",
+				"VINYLDNS_SHARED_CREDENTIALS_FILE": file,
+			},
+			Raw:      map[string]interface{}{},
+			Expected: credentials{Host: "https://ci.example.com", AccessKey: "ciAccessKey", SecretKey: "ciSecretKey"},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			t.Setenv("HOME", t.TempDir())
+			for _, k := range []string{"VINYLDNS_HOST", "VINYLDNS_ACCESS_KEY", "VINYLDNS_SECRET_KEY", "VINYLDNS_CREDENTIAL_PROCESS", "VINYLDNS_PROFILE", "VINYLDNS_SHARED_CREDENTIALS_FILE"} {
+				t.Setenv(k, tc.Env[k])
+			}
+
+			d := schema.TestResourceDataRaw(t, Provider().Schema, tc.Raw)
+			creds, err := resolveCredentials(credentialsConfigFromResourceData(d))
+			if err != nil {
+				t.Fatalf("did not expect an error but one was raised: %s", err)
+			}
+			if creds != tc.Expected {
+				t.Fatalf("expected %#v, got %#v", tc.Expected, creds)
+			}
+		})
+	}
+}
+
+func TestProviderConfigureValidatesCredentials(t *testing.T) {
+	respond := func(code int, body string) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/groups" {
+				t.Errorf("expected credentials to be validated by listing groups, got %s", r.URL.Path)
+			}
+			w.WriteHeader(code)
+			fmt.Fprint(w, body)
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+
+	cases := map[string]struct {
+		Host          string
+		Skip          bool
+		ExpectSummary string
+	}{
+		"valid credentials": {
+			Host: respond(http.StatusOK, `{"groups":[]}`).URL,
+		},
+		"unreachable host": {
+			Host:          unreachable.URL,
+			ExpectSummary: "Unable to reach the VinylDNS API",
+		},
+		"bad signature": {
+			Host:          respond(http.StatusUnauthorized, "Authentication Failed").URL,
+			ExpectSummary: "VinylDNS rejected the request signature",
+		},
+		"forbidden": {
+			Host:          respond(http.StatusForbidden, "Account is locked").URL,
+			ExpectSummary: "VinylDNS credentials are not allowed to use the API",
+		},
+		"not a VinylDNS API": {
+			Host:          respond(http.StatusNotFound, "Not Found").URL,
+			ExpectSummary: "Unexpected response from the VinylDNS API",
+		},
+		"not JSON": {
+			Host:          respond(http.StatusOK, "<html></html>").URL,
+			ExpectSummary: "Unexpected response from the VinylDNS API",
+		},
+		"missing host": {
+			ExpectSummary: "VinylDNS host is not set",
+		},
+		"validation skipped": {
+			Host: respond(http.StatusUnauthorized, "Authentication Failed").URL,
+			Skip: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			t.Setenv("HOME", t.TempDir())
+			t.Setenv("VINYLDNS_HOST", "")
+
+			raw := map[string]interface{}{
+				"access_key":                  "accessKey",
+				"secret_key":                  "secretKey",
+				"max_retries":                 0,
+				"skip_credentials_validation": tc.Skip,
+			}
+			if tc.Host != "" {
+				raw["host"] = tc.Host
+			}
+
+			diags := Provider().Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+			if tc.ExpectSummary == "" {
+				if diags.HasError() {
+					t.Fatalf("did not expect an error but one was raised: %#v", diags)
+				}
+				return
+			}
+
+			if !diags.HasError() {
+				t.Fatalf("expected an error but one was not raised")
+			}
+			if !strings.Contains(diags[0].Summary, tc.ExpectSummary) {
+				t.Fatalf("expected summary %q, got %q", tc.ExpectSummary, diags[0].Summary)
+			}
+		})
+	}
+}
@@ -1,16 +1,16 @@
 package vinyldns
 
 import (
-	"fmt"
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/vinyldns/go-vinyldns/vinyldns"
 )
 
 func dataSourceVinylDNSBackendIDs() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceVinylDNSBackendIDsRead,
+		ReadContext: dataSourceVinylDNSBackendIDsRead,
 
 		Schema: map[string]*schema.Schema{
 			"backend_ids": {
@@ -22,16 +22,16 @@ func dataSourceVinylDNSBackendIDs() *schema.Resource {
 	}
 }
 
-func dataSourceVinylDNSBackendIDsRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceVinylDNSBackendIDsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[INFO] Reading VinylDNS backend IDs")
 
-	ids, err := meta.(*vinyldns.Client).ZoneBackendIDs()
+	ids, err := meta.(*providerMeta).client.ZoneBackendIDs()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err := d.Set("backend_ids", ids); err != nil {
-		return fmt.Errorf("error setting backend_ids: %s", err)
+		return diag.Errorf("error setting backend_ids: %s", err)
 	}
 
 	d.SetId("backend-ids")
@@ -1,16 +1,17 @@
 package vinyldns
 
 import (
-	"fmt"
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vinyldns/go-vinyldns/vinyldns"
 )
 
 func dataSourceVinylDNSGroup() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceVinylDNSGroupRead,
+		ReadContext: dataSourceVinylDNSGroupRead,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -39,41 +40,41 @@ func dataSourceVinylDNSGroup() *schema.Resource {
 	}
 }
 
-func dataSourceVinylDNSGroupRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceVinylDNSGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	name := d.Get("name").(string)
 
 	log.Printf("[INFO] Reading VinylDNS group %s", name)
 
-	groups, err := meta.(*vinyldns.Client).GroupsListAll(vinyldns.ListFilter{
+	groups, err := meta.(*providerMeta).client.GroupsListAll(vinyldns.ListFilter{
 		NameFilter: name,
 	})
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	var match *vinyldns.Group
 	for i, g := range groups {
 		if g.Name == name {
 			if match != nil {
-				return fmt.Errorf("found multiple groups with name %s", name)
+				return diag.Errorf("found multiple groups with name %s", name)
 			}
 			match = &groups[i]
 		}
 	}
 
 	if match == nil {
-		return fmt.Errorf("no group found with name %s", name)
+		return diag.Errorf("no group found with name %s", name)
 	}
 
 	d.SetId(match.ID)
 	if err := d.Set("name", match.Name); err != nil {
-		return fmt.Errorf("error setting name for group %s: %s", match.ID, err)
+		return diag.Errorf("error setting name for group %s: %s", match.ID, err)
 	}
 	if err := d.Set("email", match.Email); err != nil {
-		return fmt.Errorf("error setting email for group %s: %s", match.ID, err)
+		return diag.Errorf("error setting email for group %s: %s", match.ID, err)
 	}
 	if err := d.Set("description", match.Description); err != nil {
-		return fmt.Errorf("error setting description for group %s: %s", match.ID, err)
+		return diag.Errorf("error setting description for group %s: %s", match.ID, err)
 	}
 
 	memIDs := make([]interface{}, 0, len(match.Members))
@@ -81,7 +82,7 @@ func dataSourceVinylDNSGroupRead(d *schema.ResourceData, meta interface{}) error
 		memIDs = append(memIDs, m.ID)
 	}
 	if err := d.Set("member_ids", schema.NewSet(schema.HashString, memIDs)); err != nil {
-		return fmt.Errorf("error setting member_ids for group %s: %s", match.ID, err)
+		return diag.Errorf("error setting member_ids for group %s: %s", match.ID, err)
 	}
 
 	adminIDs := make([]interface{}, 0, len(match.Admins))
@@ -89,7 +90,7 @@ func dataSourceVinylDNSGroupRead(d *schema.ResourceData, meta interface{}) error
 		adminIDs = append(adminIDs, a.ID)
 	}
 	if err := d.Set("admin_ids", schema.NewSet(schema.HashString, adminIDs)); err != nil {
-		return fmt.Errorf("error setting admin_ids for group %s: %s", match.ID, err)
+		return diag.Errorf("error setting admin_ids for group %s: %s", match.ID, err)
 	}
 
 	return nil
@@ -1,16 +1,18 @@
 package vinyldns
 
 import (
+	"context"
 	"fmt"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vinyldns/go-vinyldns/vinyldns"
 )
 
 func dataSourceVinylDNSGroups() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceVinylDNSGroupsRead,
+		ReadContext: dataSourceVinylDNSGroupsRead,
 
 		Schema: map[string]*schema.Schema{
 			"name_filter": {
@@ -55,16 +57,16 @@ func dataSourceVinylDNSGroups() *schema.Resource {
 	}
 }
 
-func dataSourceVinylDNSGroupsRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceVinylDNSGroupsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	nameFilter := d.Get("name_filter").(string)
 
 	log.Printf("[INFO] Reading VinylDNS groups (name_filter=%s)", nameFilter)
 
-	groups, err := meta.(*vinyldns.Client).GroupsListAll(vinyldns.ListFilter{
+	groups, err := meta.(*providerMeta).client.GroupsListAll(vinyldns.ListFilter{
 		NameFilter: nameFilter,
 	})
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	flattened := make([]interface{}, 0, len(groups))
@@ -90,7 +92,7 @@ func dataSourceVinylDNSGroupsRead(d *schema.ResourceData, meta interface{}) erro
 	}
 
 	if err := d.Set("groups", flattened); err != nil {
-		return fmt.Errorf("error setting groups: %s", err)
+		return diag.Errorf("error setting groups: %s", err)
 	}
 
 	if nameFilter == "" {
@@ -1,16 +1,17 @@
 package vinyldns
 
 import (
+	"context"
 	"fmt"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/vinyldns/go-vinyldns/vinyldns"
 )
 
 func dataSourceVinylDNSRecordSets() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceVinylDNSRecordSetsRead,
+		ReadContext: dataSourceVinylDNSRecordSetsRead,
 
 		Schema: map[string]*schema.Schema{
 			"zone_id": {
@@ -61,19 +62,15 @@ func dataSourceVinylDNSRecordSets() *schema.Resource {
 	}
 }
 
-func dataSourceVinylDNSRecordSetsRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceVinylDNSRecordSetsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	zoneID := d.Get("zone_id").(string)
 	nameFilter := d.Get("name_filter").(string)
 
 	log.Printf("[INFO] Reading VinylDNS record sets (zone_id=%s name_filter=%s)", zoneID, nameFilter)
 
-	filter := vinyldns.ListFilter{
-		NameFilter: nameFilter,
-	}
-
-	records, err := meta.(*vinyldns.Client).RecordSetsListAll(zoneID, filter)
+	records, err := listRecordSets(ctx, meta.(*providerMeta).client, zoneID, nameFilter)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	flattened := make([]interface{}, 0, len(records))
@@ -90,7 +87,7 @@ func dataSourceVinylDNSRecordSetsRead(d *schema.ResourceData, meta interface{})
 	}
 
 	if err := d.Set("record_sets", flattened); err != nil {
-		return fmt.Errorf("error setting record_sets for zone %s: %s", zoneID, err)
+		return diag.Errorf("error setting record_sets for zone %s: %s", zoneID, err)
 	}
 
 	d.SetId(fmt.Sprintf("%s:%s", zoneID, nameFilter))
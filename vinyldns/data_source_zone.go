@@ -1,16 +1,17 @@
 package vinyldns
 
 import (
-	"fmt"
+	"context"
 	"log"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/vinyldns/go-vinyldns/vinyldns"
 )
 
 func dataSourceVinylDNSZone() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceVinylDNSZoneRead,
+		ReadContext: dataSourceVinylDNSZoneRead,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -29,21 +30,21 @@ func dataSourceVinylDNSZone() *schema.Resource {
 	}
 }
 
-func dataSourceVinylDNSZoneRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceVinylDNSZoneRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var name string
 	if n, ok := d.GetOk("name"); ok {
 		name = n.(string)
 	}
 
 	if name == "" {
-		return fmt.Errorf("%s must be provided", "name")
+		return attributeError(cty.GetAttrPath("name"), "%s must be provided", "name")
 	}
 
 	log.Printf("[INFO] Reading VinylDNS zone %s", name)
 
-	z, err := meta.(*vinyldns.Client).ZoneByName(name)
+	z, err := meta.(*providerMeta).client.ZoneByName(name)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(z.ID)
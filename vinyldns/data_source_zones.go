@@ -1,16 +1,18 @@
 package vinyldns
 
 import (
+	"context"
 	"fmt"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vinyldns/go-vinyldns/vinyldns"
 )
 
 func dataSourceVinylDNSZones() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceVinylDNSZonesRead,
+		ReadContext: dataSourceVinylDNSZonesRead,
 
 		Schema: map[string]*schema.Schema{
 			"name_filter": {
@@ -57,7 +59,7 @@ func dataSourceVinylDNSZones() *schema.Resource {
 	}
 }
 
-func dataSourceVinylDNSZonesRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceVinylDNSZonesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	nameFilter := d.Get("name_filter").(string)
 
 	log.Printf("[INFO] Reading VinylDNS zones (name_filter=%s)", nameFilter)
@@ -66,9 +68,9 @@ func dataSourceVinylDNSZonesRead(d *schema.ResourceData, meta interface{}) error
 		NameFilter: nameFilter,
 	}
 
-	zones, err := meta.(*vinyldns.Client).ZonesListAll(filter)
+	zones, err := meta.(*providerMeta).client.ZonesListAll(filter)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	flattened := make([]interface{}, 0, len(zones))
@@ -85,7 +87,7 @@ func dataSourceVinylDNSZonesRead(d *schema.ResourceData, meta interface{}) error
 	}
 
 	if err := d.Set("zones", flattened); err != nil {
-		return fmt.Errorf("error setting zones: %s", err)
+		return diag.Errorf("error setting zones: %s", err)
 	}
 
 	if nameFilter == "" {
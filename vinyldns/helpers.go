@@ -13,10 +13,16 @@ limitations under the License.
 package vinyldns
 
 import (
+	"encoding/hex"
 	"fmt"
+	"net/netip"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/net/idna"
 )
 
 func stringSetToStringSlice(stringSet *schema.Set) []string {
@@ -40,7 +46,225 @@ func parseTwoPartID(id string) (string, string, error) {
 	return parts[0], parts[1], nil
 }
 
+// attributeError returns an error diagnostic pointing at the attribute at
+// path, so Terraform highlights it in the configuration.
+func attributeError(path cty.Path, format string, a ...interface{}) diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity:      diag.Error,
+		Summary:       fmt.Sprintf(format, a...),
+		AttributePath: path,
+	}}
+}
+
 // vinyldns responds 400 to IPv6 addresses represented within `[` `]`
 func removeBrackets(str string) string {
 	return strings.Replace(strings.Replace(str, "[", "", -1), "]", "", -1)
 }
+
+// canonicalAddress returns an IP address without brackets and in canonical
+// form: IPv6 addresses in lower case with the longest run of zero groups
+// compressed, as in RFC 5952. IPv4-mapped IPv6 addresses stay IPv6
+// addresses. Values that are not IP addresses are returned without brackets
+// but otherwise unchanged.
+func canonicalAddress(address string) string {
+	address = removeBrackets(address)
+
+	addr, err := netip.ParseAddr(address)
+	if err != nil {
+		return address
+	}
+
+	return addr.String()
+}
+
+func validateDuration(v interface{}, k string) (ws []string, errors []error) {
+	value, ok := v.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a duration such as \"30s\" or \"1m\": %s", k, err))
+		return
+	}
+
+	if d < 0 {
+		errors = append(errors, fmt.Errorf("%q must not be negative, got %s", k, value))
+	}
+
+	return
+}
+
+// validateTrailingDot validates that a domain name is fully qualified, as
+// VinylDNS requires of record targets.
+func validateTrailingDot(v interface{}, k string) (ws []string, errors []error) {
+	value, ok := v.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if !strings.HasSuffix(value, ".") {
+		errors = append(errors, fmt.Errorf("%q must end in trailing '.', got %q", k, value))
+	}
+
+	return
+}
+
+// validateHexString validates that a value is non-empty and hex encoded.
+func validateHexString(v interface{}, k string) (ws []string, errors []error) {
+	value, ok := v.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if _, err := hex.DecodeString(value); err != nil || value == "" {
+		errors = append(errors, fmt.Errorf("%q must be hex encoded, got %q", k, value))
+	}
+
+	return
+}
+
+// validateSSHFPFingerprint validates that an SSHFP fingerprint is a hex
+// encoded SHA-1 (40 digits) or SHA-256 (64 digits) digest.
+func validateSSHFPFingerprint(v interface{}, k string) (ws []string, errors []error) {
+	value, ok := v.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if _, err := hex.DecodeString(value); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be hex encoded, got %q", k, value))
+		return
+	}
+
+	if len(value) != 40 && len(value) != 64 {
+		errors = append(errors, fmt.Errorf("%q must be a SHA-1 (40 hex digits) or SHA-256 (64 hex digits) fingerprint, got %d hex digits", k, len(value)))
+	}
+
+	return
+}
+
+// asciiName returns a domain name with its internationalized labels
+// converted to punycode, the form VinylDNS stores. Labels that are already
+// ASCII, including the "_", "*" and "/" labels IDNA does not allow, are left
+// as they are.
+func asciiName(name string) (string, error) {
+	labels := strings.Split(name, ".")
+	for i, label := range labels {
+		if isASCII(label) {
+			continue
+		}
+
+		ascii, err := idna.Lookup.ToASCII(label)
+		if err != nil {
+			return "", fmt.Errorf("%q is not a valid internationalized domain name: %s", name, err)
+		}
+		labels[i] = ascii
+	}
+
+	return strings.Join(labels, "."), nil
+}
+
+// unicodeName returns a domain name with its punycode labels converted back
+// to Unicode, leaving any label that is not valid punycode as it is.
+func unicodeName(name string) string {
+	labels := strings.Split(name, ".")
+	for i, label := range labels {
+		if !strings.HasPrefix(strings.ToLower(label), "xn--") {
+			continue
+		}
+
+		if unicode, err := idna.Lookup.ToUnicode(label); err == nil && unicode != "" {
+			labels[i] = unicode
+		}
+	}
+
+	return strings.Join(labels, ".")
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// validateZoneName validates that a zone name, which may contain Unicode
+// labels, can be converted to punycode.
+func validateZoneName(v interface{}, k string) (ws []string, errors []error) {
+	value, ok := v.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if _, err := asciiName(value); err != nil {
+		errors = append(errors, fmt.Errorf("%q: %s", k, err))
+	}
+
+	return
+}
+
+// validateRecordName validates a record set name: "@", or dot separated labels of
+// at most 63 characters, made up of letters, digits, hyphens, underscores,
+// the '*' of a wildcard and the '/' of a classless reverse delegation, at
+// most 255 characters in all. Unicode labels are checked in their punycode
+// form.
+func validateRecordName(v interface{}, k string) (ws []string, errors []error) {
+	value, ok := v.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if value == "" {
+		errors = append(errors, fmt.Errorf("%q must not be empty", k))
+		return
+	}
+
+	// "@" names the zone apex
+	if value == "@" {
+		return
+	}
+
+	value, err := asciiName(value)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q: %s", k, err))
+		return
+	}
+
+	if len(value) > 255 {
+		errors = append(errors, fmt.Errorf("%q must be at most 255 characters, got %d", k, len(value)))
+		return
+	}
+
+	labels := strings.Split(strings.TrimSuffix(value, "."), ".")
+	for _, label := range labels {
+		if label == "" {
+			errors = append(errors, fmt.Errorf("%q must not contain empty labels, got %q", k, value))
+			return
+		}
+
+		if len(label) > 63 {
+			errors = append(errors, fmt.Errorf("%q labels must be at most 63 characters, got %q", k, label))
+			return
+		}
+
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_*/", r)) {
+				errors = append(errors, fmt.Errorf("%q contains invalid character %q, got %q", k, r, value))
+				return
+			}
+		}
+	}
+
+	return
+}
@@ -14,6 +14,7 @@ package vinyldns
 
 import (
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -54,6 +55,90 @@ func Test_removeBrackets(t *testing.T) {
 	}
 }
 
+func TestASCIIName(t *testing.T) {
+	cases := map[string]struct {
+		Name        string
+		Expected    string
+		ExpectError bool
+	}{
+		"ascii":             {Name: "www.example.com.", Expected: "www.example.com."},
+		"ascii keeps case":  {Name: "WWW", Expected: "WWW"},
+		"unicode":           {Name: "bücher.example.", Expected: "xn--bcher-kva.example."},
+		"unicode case":      {Name: "BÜCHER", Expected: "xn--bcher-kva"},
+		"decomposed":        {Name: "bu\u0308cher", Expected: "xn--bcher-kva"},
+		"punycode":          {Name: "xn--bcher-kva", Expected: "xn--bcher-kva"},
+		"service labels":    {Name: "_sip._tcp.bücher", Expected: "_sip._tcp.xn--bcher-kva"},
+		"wildcard":          {Name: "*.bücher", Expected: "*.xn--bcher-kva"},
+		"invalid character": {Name: "bü\u200dcher", ExpectError: true},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got, err := asciiName(tc.Name)
+			if tc.ExpectError {
+				if err == nil {
+					t.Fatalf("expected an error but one was not raised")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect an error but one was raised: %s", err)
+			}
+			if got != tc.Expected {
+				t.Fatalf("expected %q, got %q", tc.Expected, got)
+			}
+		})
+	}
+}
+
+func TestUnicodeName(t *testing.T) {
+	cases := map[string]struct {
+		Name     string
+		Expected string
+	}{
+		"ascii":            {Name: "www.example.com.", Expected: "www.example.com."},
+		"punycode":         {Name: "xn--bcher-kva.example.", Expected: "bücher.example."},
+		"invalid punycode": {Name: "xn--.example.", Expected: "xn--.example."},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			if got := unicodeName(tc.Name); got != tc.Expected {
+				t.Fatalf("expected %q, got %q", tc.Expected, got)
+			}
+		})
+	}
+}
+
+func TestCanonicalAddress(t *testing.T) {
+	cases := map[string]struct {
+		Address  string
+		Expected string
+	}{
+		"IPv4":                     {Address: "192.0.2.1", Expected: "192.0.2.1"},
+		"compressed":               {Address: "2001:db8::1", Expected: "2001:db8::1"},
+		"upper case":               {Address: "2001:DB8::1", Expected: "2001:db8::1"},
+		"partially compressed":     {Address: "2001:DB8:0:0::1", Expected: "2001:db8::1"},
+		"expanded":                 {Address: "2001:0db8:0000:0000:0000:0000:0000:0001", Expected: "2001:db8::1"},
+		"longest zero run":         {Address: "2001:db8:0:0:1:0:0:0", Expected: "2001:db8:0:0:1::"},
+		"bracketed":                {Address: "[2001:db8::1]", Expected: "2001:db8::1"},
+		"bracketed expanded":       {Address: "[2001:0DB8:0:0:0:0:0:1]", Expected: "2001:db8::1"},
+		"IPv4-mapped":              {Address: "::ffff:192.0.2.1", Expected: "::ffff:192.0.2.1"},
+		"IPv4-mapped hex":          {Address: "::ffff:c000:201", Expected: "::ffff:192.0.2.1"},
+		"IPv4-mapped expanded":     {Address: "0:0:0:0:0:FFFF:192.0.2.1", Expected: "::ffff:192.0.2.1"},
+		"not an address":           {Address: "www.example.com", Expected: "www.example.com"},
+		"bracketed not an address": {Address: "[nonsense]", Expected: "nonsense"},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			if got := canonicalAddress(tc.Address); got != tc.Expected {
+				t.Fatalf("expected %q, got %q", tc.Expected, got)
+			}
+		})
+	}
+}
+
 func testPrimaryServer() string {
 	if v := os.Getenv("VINYLDNS_TEST_PRIMARY_SERVER"); v != "" {
 		return v
@@ -69,3 +154,117 @@ func testZoneName() string {
 
 	return "ok."
 }
+
+func TestValidateTrailingDot(t *testing.T) {
+	cases := map[string]struct {
+		Value       interface{}
+		ExpectError bool
+	}{
+		"fully qualified": {Value: "sip.example.com."},
+		"root":            {Value: "."},
+		"relative":        {Value: "sip.example.com", ExpectError: true},
+		"empty":           {Value: "", ExpectError: true},
+		"not string":      {Value: 1, ExpectError: true},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			_, errs := validateTrailingDot(tc.Value, "target")
+			if tc.ExpectError && len(errs) == 0 {
+				t.Fatalf("expected an error but one was not raised")
+			}
+			if !tc.ExpectError && len(errs) != 0 {
+				t.Fatalf("did not expect an error but one was raised: %v", errs)
+			}
+		})
+	}
+}
+
+func TestValidateHexString(t *testing.T) {
+	cases := map[string]struct {
+		Value       interface{}
+		ExpectError bool
+	}{
+		"lower case": {Value: "2bb183af5f22588179a53b0a98631fad1a292118"},
+		"upper case": {Value: "2BB183AF5F22588179A53B0A98631FAD1A292118"},
+		"odd length": {Value: "2bb", ExpectError: true},
+		"not hex":    {Value: "zz", ExpectError: true},
+		"empty":      {Value: "", ExpectError: true},
+		"not string": {Value: 1, ExpectError: true},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			_, errs := validateHexString(tc.Value, "digest")
+			if tc.ExpectError && len(errs) == 0 {
+				t.Fatalf("expected an error but one was not raised")
+			}
+			if !tc.ExpectError && len(errs) != 0 {
+				t.Fatalf("did not expect an error but one was raised: %v", errs)
+			}
+		})
+	}
+}
+
+func TestValidateSSHFPFingerprint(t *testing.T) {
+	cases := map[string]struct {
+		Value       interface{}
+		ExpectError bool
+	}{
+		"sha1":         {Value: "123456789abcdef67890123456789abcdef67890"},
+		"sha256":       {Value: "1234567890ABCDEF1234567890ABCDEF1234567890ABCDEF1234567890ABCDEF"},
+		"wrong length": {Value: "123456789abcdef6", ExpectError: true},
+		"odd length":   {Value: "123456789abcdef67890123456789abcdef6789", ExpectError: true},
+		"not hex":      {Value: "z23456789abcdef67890123456789abcdef67890", ExpectError: true},
+		"empty":        {Value: "", ExpectError: true},
+		"not string":   {Value: 1, ExpectError: true},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			_, errs := validateSSHFPFingerprint(tc.Value, "fingerprint")
+			if tc.ExpectError && len(errs) == 0 {
+				t.Fatalf("expected an error but one was not raised")
+			}
+			if !tc.ExpectError && len(errs) != 0 {
+				t.Fatalf("did not expect an error but one was raised: %v", errs)
+			}
+		})
+	}
+}
+
+func TestValidateRecordName(t *testing.T) {
+	cases := map[string]struct {
+		Value       interface{}
+		ExpectError bool
+	}{
+		"single label":      {Value: "www"},
+		"several labels":    {Value: "_sip._tcp.voip"},
+		"fully qualified":   {Value: "www.example.com."},
+		"wildcard":          {Value: "*.apps"},
+		"classless reverse": {Value: "0/25"},
+		"apex":              {Value: "@"},
+		"unicode":           {Value: "bücher.example."},
+		"invalid unicode":   {Value: "bü\u200dcher", ExpectError: true},
+		"empty":             {Value: "", ExpectError: true},
+		"empty label":       {Value: "www..example", ExpectError: true},
+		"leading dot":       {Value: ".www", ExpectError: true},
+		"space":             {Value: "my host", ExpectError: true},
+		"long label":        {Value: strings.Repeat("a", 64), ExpectError: true},
+		"long name":         {Value: strings.Repeat("a.", 128), ExpectError: true},
+		"invalid character": {Value: "www!", ExpectError: true},
+		"not string":        {Value: 1, ExpectError: true},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			_, errs := validateRecordName(tc.Value, "name")
+			if tc.ExpectError && len(errs) == 0 {
+				t.Fatalf("expected an error but one was not raised")
+			}
+			if !tc.ExpectError && len(errs) != 0 {
+				t.Fatalf("did not expect an error but one was raised: %v", errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2018 Comcast Cable Communications Management, LLC
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vinyldns
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vinyldns/go-vinyldns/vinyldns"
+)
+
+// VinylDNS identifies zones, record sets and groups by UUID, which lets an
+// import ID be told apart from a name.
+var uuidRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// resourceVinylDNSRecordSetImport accepts either the zone_id:record_set_id
+// form of the resource ID or zone_name/record_name/TYPE, which it resolves
+// to the record set's ID. The record name may be "@", relative to the zone
+// or fully qualified, as in the name argument. Either way it checks the
+// record set exists, as Read would otherwise treat a mistyped ID as a
+// deleted record set and the import would report success without importing
+// anything.
+func resourceVinylDNSRecordSetImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if strings.Contains(d.Id(), ":") {
+		zID, rsID, err := parseTwoPartID(d.Id())
+		if err == nil && (zID == "" || rsID == "") {
+			err = fmt.Errorf("Unexpected ID format (%q). Expected zone_id:record_set_id", d.Id())
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		log.Printf("[INFO] Checking record set %s in zone %s exists to import it", rsID, zID)
+		if _, err := getRecordSet(ctx, meta.(*providerMeta).client, zID, rsID); err != nil {
+			return nil, importLookupError(fmt.Sprintf("record set %s in zone %s", rsID, zID), err)
+		}
+
+		return []*schema.ResourceData{d}, nil
+	}
+
+	zoneName, recordName, recordType, ok := splitRecordSetImportID(d.Id())
+	if !ok {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected zone_id:record_set_id or zone_name/record_name/TYPE", d.Id())
+	}
+
+	zone, err := zoneByName(meta, zoneName, importLookupError)
+	if err != nil {
+		return nil, err
+	}
+
+	recordName, err = canonicalRecordName(recordName, zone.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[INFO] Looking up %s record set %q in zone %s to import", recordType, recordName, zone.ID)
+	rss, err := listRecordSets(ctx, meta.(*providerMeta).client, zone.ID, recordName)
+	if err != nil {
+		return nil, fmt.Errorf("error listing record sets in zone %q: %s", zoneName, err)
+	}
+
+	var matches []recordSet
+	for _, rs := range rss {
+		if strings.EqualFold(rs.Name, recordName) && strings.EqualFold(rs.Type, recordType) {
+			matches = append(matches, rs)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no %s record set named %q found in zone %q", strings.ToUpper(recordType), recordName, zoneName)
+	case 1:
+		d.SetId(fmt.Sprintf("%s:%s", zone.ID, matches[0].ID))
+		return []*schema.ResourceData{d}, nil
+	}
+
+	ids := make([]string, 0, len(matches))
+	for _, rs := range matches {
+		ids = append(ids, fmt.Sprintf("%s:%s", zone.ID, rs.ID))
+	}
+
+	return nil, fmt.Errorf("%d %s record sets named %q found in zone %q, import one by ID instead: %s",
+		len(matches), strings.ToUpper(recordType), recordName, zoneName, strings.Join(ids, ", "))
+}
+
+// splitRecordSetImportID splits a zone_name/record_name/TYPE import ID. Both
+// names may contain a "/", as classless reverse zones and delegations do, so
+// a zone name written with its trailing dot ends at the first "./", and one
+// written without it at the first "/".
+func splitRecordSetImportID(id string) (zoneName, recordName, recordType string, ok bool) {
+	i := strings.LastIndex(id, "/")
+	if i < 0 {
+		return "", "", "", false
+	}
+	rest, recordType := id[:i], id[i+1:]
+
+	if j := strings.Index(rest, "./"); j >= 0 {
+		zoneName, recordName = rest[:j+1], rest[j+2:]
+	} else if j := strings.Index(rest, "/"); j >= 0 {
+		zoneName, recordName = rest[:j], rest[j+1:]
+	}
+
+	if zoneName == "" || zoneName == "." || recordName == "" || recordType == "" {
+		return "", "", "", false
+	}
+
+	return zoneName, recordName, recordType, true
+}
+
+// resourceVinylDNSZoneImport accepts either a zone ID or a zone name, which
+// it resolves to the zone's ID, and checks the zone exists.
+func resourceVinylDNSZoneImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if uuidRegexp.MatchString(d.Id()) {
+		log.Printf("[INFO] Checking zone %s exists to import it", d.Id())
+		if _, err := meta.(*providerMeta).client.Zone(d.Id()); err != nil {
+			return nil, importLookupError(fmt.Sprintf("zone %s", d.Id()), err)
+		}
+
+		return []*schema.ResourceData{d}, nil
+	}
+
+	zone, err := zoneByName(meta, d.Id(), importLookupError)
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(zone.ID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceVinylDNSGroupImport accepts either a group ID or a group name,
+// which it resolves to the group's ID, and checks the group exists. Only
+// groups the provider's credentials are a member of can be found by name.
+func resourceVinylDNSGroupImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if uuidRegexp.MatchString(d.Id()) {
+		log.Printf("[INFO] Checking group %s exists to import it", d.Id())
+		if _, err := meta.(*providerMeta).client.Group(d.Id()); err != nil {
+			return nil, importLookupError(fmt.Sprintf("group %s", d.Id()), err)
+		}
+
+		return []*schema.ResourceData{d}, nil
+	}
+
+	group, err := groupByName(meta, d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(group.ID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// importLookupError describes a failure to read the object being imported.
+func importLookupError(object string, err error) error {
+	if vErr, ok := err.(*vinyldns.Error); ok {
+		switch vErr.ResponseCode {
+		case http.StatusNotFound:
+			return fmt.Errorf("cannot import %s: it does not exist", object)
+		case http.StatusForbidden:
+			return fmt.Errorf("cannot import %s: the provider's credentials are not allowed to read it", object)
+		}
+	}
+
+	return fmt.Errorf("cannot import %s: %s", object, err)
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2018 Comcast Cable Communications Management, LLC
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vinyldns
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	testImportZoneID  = "9cbdd3ac-9752-4d56-9ca0-6a1a14fc5562"
+	testImportGroupID = "2b5f1ad8-6a3b-4c6e-9d4e-0f7e1c2a3b4d"
+)
+
+func TestResourceVinylDNSRecordSetImport(t *testing.T) {
+	routes := map[string]string{
+		"/zones/name/example.com.":               `{"zone": {"id": "` + testImportZoneID + `", "name": "example.com."}}`,
+		"/zones/name/missing.example.com.":       "",
+		"/zones/name/example.com":                `{"zone": {"id": "` + testImportZoneID + `", "name": "example.com."}}`,
+		"/zones/name/0/25.2.0.192.in-addr.arpa.": `{"zone": {"id": "classless-zone-id", "name": "0/25.2.0.192.in-addr.arpa."}}`,
+		"/zones/classless-zone-id/recordsets": `{"recordSets": [
+			{"id": "rs-classless-ptr", "zoneId": "classless-zone-id", "name": "10", "type": "PTR"}
+		]}`,
+		"/zones/zone-id/recordsets/rs-id":  `{"recordSet": {"id": "rs-id", "zoneId": "zone-id", "name": "www", "type": "A"}}`,
+		"/zones/zone-id/recordsets/typo":   "",
+		"/zones/zone-id/recordsets/secret": testForbidden,
+		"/zones/" + testImportZoneID + "/recordsets": `{"recordSets": [
+			{"id": "rs-www-a", "zoneId": "` + testImportZoneID + `", "name": "www", "type": "A"},
+			{"id": "rs-www-txt", "zoneId": "` + testImportZoneID + `", "name": "www", "type": "TXT"},
+			{"id": "rs-www2-a", "zoneId": "` + testImportZoneID + `", "name": "www2", "type": "A"},
+			{"id": "rs-mx-1", "zoneId": "` + testImportZoneID + `", "name": "mail", "type": "MX"},
+			{"id": "rs-mx-2", "zoneId": "` + testImportZoneID + `", "name": "mail", "type": "MX"},
+			{"id": "rs-classless", "zoneId": "` + testImportZoneID + `", "name": "0/25", "type": "NS"},
+			{"id": "rs-apex-ns", "zoneId": "` + testImportZoneID + `", "name": "example.com.", "type": "NS"}
+		]}`,
+	}
+
+	cases := map[string]struct {
+		ID          string
+		ExpectID    string
+		ExpectError string
+	}{
+		"zone and record set IDs": {
+			ID:       "zone-id:rs-id",
+			ExpectID: "zone-id:rs-id",
+		},
+		"record set not found": {
+			ID:          "zone-id:typo",
+			ExpectError: "cannot import record set typo in zone zone-id: it does not exist",
+		},
+		"record set forbidden": {
+			ID:          "zone-id:secret",
+			ExpectError: "cannot import record set secret in zone zone-id: the provider's credentials are not allowed to read it",
+		},
+		"malformed IDs": {
+			ID:          "zone-id:rs-id:extra",
+			ExpectError: "Expected zone_id:record_set_id",
+		},
+		"missing record set ID": {
+			ID:          "zone-id:",
+			ExpectError: "Expected zone_id:record_set_id",
+		},
+		"names": {
+			ID:       "example.com./www/A",
+			ExpectID: testImportZoneID + ":rs-www-a",
+		},
+		"lower case type": {
+			ID:       "example.com./www/txt",
+			ExpectID: testImportZoneID + ":rs-www-txt",
+		},
+		"record name containing a slash": {
+			ID:       "example.com./0/25/NS",
+			ExpectID: testImportZoneID + ":rs-classless",
+		},
+		"zone name without trailing dot": {
+			ID:       "example.com/www/A",
+			ExpectID: testImportZoneID + ":rs-www-a",
+		},
+		"apex written @": {
+			ID:       "example.com./@/NS",
+			ExpectID: testImportZoneID + ":rs-apex-ns",
+		},
+		"fully qualified record name": {
+			ID:       "example.com./www.example.com./A",
+			ExpectID: testImportZoneID + ":rs-www-a",
+		},
+		"record name outside the zone": {
+			ID:          "example.com./www.example.org./A",
+			ExpectError: `"www.example.org." is not within zone "example.com."`,
+		},
+		"classless zone": {
+			ID:       "0/25.2.0.192.in-addr.arpa./10/PTR",
+			ExpectID: "classless-zone-id:rs-classless-ptr",
+		},
+		"no such record set": {
+			ID:          "example.com./www/AAAA",
+			ExpectError: `no AAAA record set named "www" found in zone "example.com."`,
+		},
+		"ambiguous": {
+			ID:          "example.com./mail/MX",
+			ExpectError: `2 MX record sets named "mail" found in zone "example.com.", import one by ID instead`,
+		},
+		"no such zone": {
+			ID:          "missing.example.com./www/A",
+			ExpectError: `no zone named "missing.example.com." found`,
+		},
+		"malformed": {
+			ID:          "example.com./www",
+			ExpectError: "expected zone_id:record_set_id or zone_name/record_name/TYPE",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			meta := testAPIServerRoutes(t, routes)
+
+			d := schema.TestResourceDataRaw(t, resourceVinylDNSRecordSet().Schema, map[string]interface{}{})
+			d.SetId(tc.ID)
+
+			testImport(t, resourceVinylDNSRecordSetImport, d, meta, tc.ExpectID, tc.ExpectError)
+		})
+	}
+}
+
+func TestSplitRecordSetImportID(t *testing.T) {
+	cases := map[string]struct {
+		ID           string
+		ExpectZone   string
+		ExpectRecord string
+		ExpectType   string
+		ExpectOK     bool
+	}{
+		"names":                  {ID: "example.com./www/A", ExpectZone: "example.com.", ExpectRecord: "www", ExpectType: "A", ExpectOK: true},
+		"no trailing dot":        {ID: "example.com/www/A", ExpectZone: "example.com", ExpectRecord: "www", ExpectType: "A", ExpectOK: true},
+		"slash in record name":   {ID: "2.0.192.in-addr.arpa./0/25/NS", ExpectZone: "2.0.192.in-addr.arpa.", ExpectRecord: "0/25", ExpectType: "NS", ExpectOK: true},
+		"slash in zone name":     {ID: "0/25.2.0.192.in-addr.arpa./10/PTR", ExpectZone: "0/25.2.0.192.in-addr.arpa.", ExpectRecord: "10", ExpectType: "PTR", ExpectOK: true},
+		"fully qualified record": {ID: "example.com./www.example.com./A", ExpectZone: "example.com.", ExpectRecord: "www.example.com.", ExpectType: "A", ExpectOK: true},
+		"missing type":           {ID: "example.com./www/"},
+		"missing record":         {ID: "example.com./A"},
+		"missing zone":           {ID: "/www/A"},
+		"no separators":          {ID: "www"},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			zone, record, recordType, ok := splitRecordSetImportID(tc.ID)
+			if ok != tc.ExpectOK || zone != tc.ExpectZone || record != tc.ExpectRecord || recordType != tc.ExpectType {
+				t.Fatalf("expected (%q, %q, %q, %t), got (%q, %q, %q, %t)",
+					tc.ExpectZone, tc.ExpectRecord, tc.ExpectType, tc.ExpectOK, zone, record, recordType, ok)
+			}
+		})
+	}
+}
+
+func TestResourceVinylDNSZoneImport(t *testing.T) {
+	routes := map[string]string{
+		"/zones/name/example.com.":         `{"zone": {"id": "` + testImportZoneID + `", "name": "example.com."}}`,
+		"/zones/name/missing.example.com.": "",
+		"/zones/name/secret.example.com.":  testForbidden,
+		"/zones/" + testImportZoneID:       `{"zone": {"id": "` + testImportZoneID + `", "name": "example.com."}}`,
+		"/zones/" + testImportGroupID:      "",
+	}
+
+	cases := map[string]struct {
+		ID          string
+		ExpectID    string
+		ExpectError string
+	}{
+		"ID": {
+			ID:       testImportZoneID,
+			ExpectID: testImportZoneID,
+		},
+		"name": {
+			ID:       "example.com.",
+			ExpectID: testImportZoneID,
+		},
+		"no such zone": {
+			ID:          "missing.example.com.",
+			ExpectError: `no zone named "missing.example.com." found`,
+		},
+		"zone forbidden": {
+			ID:          "secret.example.com.",
+			ExpectError: `cannot import zone "secret.example.com.": the provider's credentials are not allowed to read it`,
+		},
+		"no zone with ID": {
+			ID:          testImportGroupID,
+			ExpectError: "cannot import zone " + testImportGroupID + ": it does not exist",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			meta := testAPIServerRoutes(t, routes)
+
+			d := schema.TestResourceDataRaw(t, resourceVinylDNSZone().Schema, map[string]interface{}{})
+			d.SetId(tc.ID)
+
+			testImport(t, resourceVinylDNSZoneImport, d, meta, tc.ExpectID, tc.ExpectError)
+		})
+	}
+}
+
+func TestResourceVinylDNSGroupImport(t *testing.T) {
+	routes := map[string]string{
+		"/groups": `{"groups": [
+			{"id": "` + testImportGroupID + `", "name": "dns-admins"},
+			{"id": "group-2", "name": "dns-admins-eu"},
+			{"id": "group-3", "name": "twins"},
+			{"id": "group-4", "name": "twins"}
+		]}`,
+		"/groups/" + testImportGroupID: `{"id": "` + testImportGroupID + `", "name": "dns-admins"}`,
+		"/groups/" + testImportZoneID:  "",
+	}
+
+	cases := map[string]struct {
+		ID          string
+		ExpectID    string
+		ExpectError string
+	}{
+		"ID": {
+			ID:       testImportGroupID,
+			ExpectID: testImportGroupID,
+		},
+		"name": {
+			ID:       "dns-admins",
+			ExpectID: testImportGroupID,
+		},
+		"no group with ID": {
+			ID:          testImportZoneID,
+			ExpectError: "cannot import group " + testImportZoneID + ": it does not exist",
+		},
+		"no such group": {
+			ID:          "dns",
+			ExpectError: `no group named "dns" found`,
+		},
+		"ambiguous": {
+			ID:          "twins",
+			ExpectError: `2 groups named "twins" found, use the group ID instead: group-3, group-4`,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			meta := testAPIServerRoutes(t, routes)
+
+			d := schema.TestResourceDataRaw(t, resourceVinylDNSGroup().Schema, map[string]interface{}{})
+			d.SetId(tc.ID)
+
+			testImport(t, resourceVinylDNSGroupImport, d, meta, tc.ExpectID, tc.ExpectError)
+		})
+	}
+}
+
+func testImport(t *testing.T, f schema.StateContextFunc, d *schema.ResourceData, meta *providerMeta, expectID, expectError string) {
+	t.Helper()
+
+	imported, err := f(context.Background(), d, meta)
+	if expectError != "" {
+		if err == nil || !strings.Contains(err.Error(), expectError) {
+			t.Fatalf("expected an error containing %q, got %v", expectError, err)
+		}
+		return
+	}
+
+	if err != nil {
+		t.Fatalf("did not expect an error but one was raised: %s", err)
+	}
+	if len(imported) != 1 || imported[0].Id() != expectID {
+		t.Fatalf("expected to import ID %s, got %v", expectID, imported)
+	}
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2018 Comcast Cable Communications Management, LLC
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vinyldns
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vinyldns/go-vinyldns/vinyldns"
+)
+
+// lookupUnknownID sets idKey to the ID of the object named by nameKey when
+// idKey is empty. CustomizeDiff resolves names to IDs when planning, but
+// leaves the ID unknown when the name itself is not yet known, such as when
+// it comes from another resource, so the name must be looked up again when
+// applying.
+func lookupUnknownID(d *schema.ResourceData, idKey, nameKey string, lookup func(name string) (string, error)) diag.Diagnostics {
+	if d.Get(idKey).(string) != "" {
+		return nil
+	}
+
+	name := d.Get(nameKey).(string)
+	if name == "" {
+		return nil
+	}
+
+	id, err := lookup(name)
+	if err != nil {
+		return attributeError(cty.GetAttrPath(nameKey), "%s", err)
+	}
+
+	if err := d.Set(idKey, id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// recordSetLookupIDs sets zone_id and owner_group_id from zone_name and
+// owner_group_name when they were not known when planning.
+func recordSetLookupIDs(d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	diags := lookupUnknownID(d, "zone_id", "zone_name", func(name string) (string, error) {
+		zone, err := zoneByName(meta, name, lookupError)
+		return zone.ID, err
+	})
+	if diags.HasError() {
+		return diags
+	}
+
+	if d.Get("zone_id").(string) == "" {
+		return attributeError(cty.GetAttrPath("zone_id"), "the record set's zone is not known")
+	}
+
+	return lookupUnknownID(d, "owner_group_id", "owner_group_name", func(name string) (string, error) {
+		group, err := groupByName(meta, name)
+		return group.ID, err
+	})
+}
+
+// zoneLookupIDs sets admin_group_id from admin_group_name when it was not
+// known when planning.
+func zoneLookupIDs(d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	diags := lookupUnknownID(d, "admin_group_id", "admin_group_name", func(name string) (string, error) {
+		group, err := groupByName(meta, name)
+		return group.ID, err
+	})
+	if diags.HasError() {
+		return diags
+	}
+
+	if d.Get("admin_group_id").(string) == "" {
+		return attributeError(cty.GetAttrPath("admin_group_id"), "the zone's admin group is not known")
+	}
+
+	return nil
+}
+
+// zoneByName finds the zone with the given name. Errors reading it other
+// than it not existing are described by describeErr, which is
+// importLookupError when importing and lookupError otherwise.
+func zoneByName(meta interface{}, name string, describeErr func(object string, err error) error) (vinyldns.Zone, error) {
+	ascii, err := asciiName(name)
+	if err != nil {
+		return vinyldns.Zone{}, err
+	}
+
+	log.Printf("[INFO] Looking up zone %q", ascii)
+	// classless reverse zone names contain a "/", which must be escaped to
+	// keep the name in one path segment
+	zone, err := meta.(*providerMeta).client.ZoneByName(url.PathEscape(ascii))
+	if err != nil {
+		if vErr, ok := err.(*vinyldns.Error); ok && vErr.ResponseCode == http.StatusNotFound {
+			return zone, fmt.Errorf("no zone named %q found", name)
+		}
+
+		return zone, describeErr(fmt.Sprintf("zone %q", name), err)
+	}
+
+	return zone, nil
+}
+
+// lookupError describes a failure to read an object looked up while
+// planning or applying.
+func lookupError(object string, err error) error {
+	if vErr, ok := err.(*vinyldns.Error); ok {
+		switch vErr.ResponseCode {
+		case http.StatusNotFound:
+			return fmt.Errorf("%s does not exist", object)
+		case http.StatusForbidden:
+			return fmt.Errorf("%s could not be read: the provider's credentials are not allowed to read it", object)
+		}
+	}
+
+	return fmt.Errorf("%s could not be read: %s", object, err)
+}
+
+// groupByName finds the group with exactly the given name. VinylDNS only
+// lists the groups the provider's credentials are a member of, so only
+// those can be found.
+func groupByName(meta interface{}, name string) (vinyldns.Group, error) {
+	log.Printf("[INFO] Looking up group %q", name)
+	groups, err := meta.(*providerMeta).client.GroupsListAll(vinyldns.ListFilter{
+		NameFilter: url.QueryEscape(name),
+		MaxItems:   100,
+	})
+	if err != nil {
+		return vinyldns.Group{}, fmt.Errorf("error listing groups: %s", err)
+	}
+
+	var matches []vinyldns.Group
+	for _, g := range groups {
+		if g.Name == name {
+			matches = append(matches, g)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return vinyldns.Group{}, fmt.Errorf("no group named %q found among the groups the provider's credentials are a member of", name)
+	case 1:
+		return matches[0], nil
+	}
+
+	ids := make([]string, 0, len(matches))
+	for _, g := range matches {
+		ids = append(ids, g.ID)
+	}
+
+	return vinyldns.Group{}, fmt.Errorf("%d groups named %q found, use the group ID instead: %s", len(matches), name, strings.Join(ids, ", "))
+}
+
+// groupName looks up the name of the group with the given ID. A group the
+// provider's credentials cannot see leaves the name empty rather than
+// failing the read.
+func groupName(meta interface{}, groupID string) (string, diag.Diagnostics) {
+	if groupID == "" {
+		return "", nil
+	}
+
+	group, err := meta.(*providerMeta).client.Group(groupID)
+	if err != nil {
+		if vErr, ok := err.(*vinyldns.Error); ok && (vErr.ResponseCode == http.StatusNotFound || vErr.ResponseCode == http.StatusForbidden) {
+			log.Printf("[WARN] group (%s) could not be read, error code (%d)", groupID, vErr.ResponseCode)
+
+			return "", nil
+		}
+
+		return "", errorDiagnostics(fmt.Sprintf("reading group (%s)", groupID), err)
+	}
+
+	return group.Name, nil
+}
+
+// suppressZoneNameDiff treats zone names that differ only in case or in a
+// trailing dot, or that are the Unicode and punycode forms of one name, as
+// equal.
+func suppressZoneNameDiff(k, old, new string, d *schema.ResourceData) bool {
+	if ascii, err := asciiName(old); err == nil {
+		old = ascii
+	}
+	if ascii, err := asciiName(new); err == nil {
+		new = ascii
+	}
+
+	return strings.EqualFold(strings.TrimSuffix(old, "."), strings.TrimSuffix(new, "."))
+}
@@ -0,0 +1,335 @@
+/*
+Copyright 2018 Comcast Cable Communications Management, LLC
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vinyldns
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var testLookupRoutes = map[string]string{
+	"/zones/name/example.com.":         `{"zone": {"id": "zone-id", "name": "example.com."}}`,
+	"/zones/name/missing.example.com.": "",
+	"/zones/name/secret.example.com.":  testForbidden,
+	"/zones/zone-id":                   `{"zone": {"id": "zone-id", "name": "example.com."}}`,
+	"/zones/secret-zone-id":            testForbidden,
+	"/groups": `{"groups": [
+		{"id": "group-id", "name": "dns-admins"},
+		{"id": "group-2", "name": "dns-admins-eu"}
+	]}`,
+}
+
+func TestResourceVinylDNSRecordSetDiffNames(t *testing.T) {
+	cases := map[string]struct {
+		Config      map[string]interface{}
+		ExpectZone  string
+		ExpectOwner string
+		ExpectError string
+	}{
+		"IDs": {
+			Config:      map[string]interface{}{"zone_id": "other-zone-id", "owner_group_id": "other-group-id"},
+			ExpectZone:  "other-zone-id",
+			ExpectOwner: "other-group-id",
+		},
+		"names": {
+			Config:      map[string]interface{}{"zone_name": "example.com.", "owner_group_name": "dns-admins"},
+			ExpectZone:  "zone-id",
+			ExpectOwner: "group-id",
+		},
+		"no such zone": {
+			Config:      map[string]interface{}{"zone_name": "missing.example.com."},
+			ExpectError: `zone_name: no zone named "missing.example.com." found`,
+		},
+		"zone forbidden": {
+			Config:      map[string]interface{}{"zone_name": "secret.example.com."},
+			ExpectError: `zone_name: zone "secret.example.com." could not be read: the provider's credentials are not allowed to read it`,
+		},
+		"no such group": {
+			Config:      map[string]interface{}{"zone_id": "zone-id", "owner_group_name": "dns"},
+			ExpectError: `owner_group_name: no group named "dns" found`,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			meta := testAPIServerRoutes(t, testLookupRoutes)
+
+			config := map[string]interface{}{"name": "www", "type": "CNAME", "record_cname": "www.example.com."}
+			for k, v := range tc.Config {
+				config[k] = v
+			}
+
+			state := &terraform.InstanceState{RawConfig: testRawConfig(t, resourceVinylDNSRecordSet(), config)}
+
+			diff, err := resourceVinylDNSRecordSet().Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+			if tc.ExpectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.ExpectError) {
+					t.Fatalf("expected an error containing %q, got %v", tc.ExpectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect an error but one was raised: %s", err)
+			}
+
+			for k, expected := range map[string]string{"zone_id": tc.ExpectZone, "owner_group_id": tc.ExpectOwner} {
+				if attr := diff.Attributes[k]; attr == nil || attr.New != expected {
+					t.Fatalf("expected %s to be planned as %q, got %#v", k, expected, attr)
+				}
+			}
+		})
+	}
+}
+
+func TestResourceVinylDNSRecordSetDiffNameInZone(t *testing.T) {
+	cases := map[string]struct {
+		Config      map[string]interface{}
+		ExpectError string
+	}{
+		"relative": {
+			Config: map[string]interface{}{"name": "www", "zone_id": "zone-id"},
+		},
+		"absolute, zone ID": {
+			Config: map[string]interface{}{"name": "www.example.com.", "zone_id": "zone-id"},
+		},
+		"apex, zone name": {
+			Config: map[string]interface{}{"name": "@", "zone_name": "example.com."},
+		},
+		"outside the zone, zone ID": {
+			Config:      map[string]interface{}{"name": "www.example.org.", "zone_id": "zone-id"},
+			ExpectError: `name: "www.example.org." is not within zone "example.com."`,
+		},
+		"zone ID forbidden": {
+			Config:      map[string]interface{}{"name": "www.example.com.", "zone_id": "secret-zone-id"},
+			ExpectError: `zone_id: zone secret-zone-id could not be read: the provider's credentials are not allowed to read it`,
+		},
+		"outside the zone, zone name": {
+			Config:      map[string]interface{}{"name": "www.example.org.", "zone_name": "example.com."},
+			ExpectError: `name: "www.example.org." is not within zone "example.com."`,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			meta := testAPIServerRoutes(t, testLookupRoutes)
+
+			config := map[string]interface{}{"type": "CNAME", "record_cname": "www.example.com."}
+			for k, v := range tc.Config {
+				config[k] = v
+			}
+
+			state := &terraform.InstanceState{RawConfig: testRawConfig(t, resourceVinylDNSRecordSet(), config)}
+
+			_, err := resourceVinylDNSRecordSet().Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+			if tc.ExpectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.ExpectError) {
+					t.Fatalf("expected an error containing %q, got %v", tc.ExpectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect an error but one was raised: %s", err)
+			}
+		})
+	}
+}
+
+// When a name is not known until apply, such as when it comes from another
+// resource, the plan leaves the ID it names unknown, and it reaches Create
+// and Update empty.
+func TestRecordSetLookupIDs(t *testing.T) {
+	cases := map[string]struct {
+		Config      map[string]interface{}
+		ExpectZone  string
+		ExpectOwner string
+		ExpectError string
+	}{
+		"IDs": {
+			Config:      map[string]interface{}{"zone_id": "other-zone-id", "owner_group_id": "other-group-id"},
+			ExpectZone:  "other-zone-id",
+			ExpectOwner: "other-group-id",
+		},
+		"names unknown when planning": {
+			Config:      map[string]interface{}{"zone_name": "example.com.", "owner_group_name": "dns-admins"},
+			ExpectZone:  "zone-id",
+			ExpectOwner: "group-id",
+		},
+		"no owner group": {
+			Config:     map[string]interface{}{"zone_name": "example.com."},
+			ExpectZone: "zone-id",
+		},
+		"no such zone": {
+			Config:      map[string]interface{}{"zone_name": "missing.example.com."},
+			ExpectError: `no zone named "missing.example.com." found`,
+		},
+		"no such group": {
+			Config:      map[string]interface{}{"zone_id": "zone-id", "owner_group_name": "dns"},
+			ExpectError: `no group named "dns" found`,
+		},
+		"no zone": {
+			Config:      map[string]interface{}{},
+			ExpectError: "the record set's zone is not known",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			meta := testAPIServerRoutes(t, testLookupRoutes)
+
+			d := schema.TestResourceDataRaw(t, resourceVinylDNSRecordSet().Schema, tc.Config)
+
+			diags := recordSetLookupIDs(d, meta)
+			if tc.ExpectError != "" {
+				if !diags.HasError() || !strings.Contains(diags[0].Summary, tc.ExpectError) {
+					t.Fatalf("expected an error containing %q, got %#v", tc.ExpectError, diags)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("did not expect an error but one was raised: %#v", diags)
+			}
+
+			for k, expected := range map[string]string{"zone_id": tc.ExpectZone, "owner_group_id": tc.ExpectOwner} {
+				if got := d.Get(k).(string); got != expected {
+					t.Fatalf("expected %s to be %q, got %q", k, expected, got)
+				}
+			}
+		})
+	}
+}
+
+func TestZoneLookupIDs(t *testing.T) {
+	cases := map[string]struct {
+		Config      map[string]interface{}
+		Expect      string
+		ExpectError string
+	}{
+		"ID": {
+			Config: map[string]interface{}{"admin_group_id": "other-group-id"},
+			Expect: "other-group-id",
+		},
+		"name unknown when planning": {
+			Config: map[string]interface{}{"admin_group_name": "dns-admins"},
+			Expect: "group-id",
+		},
+		"no such group": {
+			Config:      map[string]interface{}{"admin_group_name": "dns"},
+			ExpectError: `no group named "dns" found`,
+		},
+		"no group": {
+			Config:      map[string]interface{}{},
+			ExpectError: "the zone's admin group is not known",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			meta := testAPIServerRoutes(t, testLookupRoutes)
+
+			d := schema.TestResourceDataRaw(t, resourceVinylDNSZone().Schema, tc.Config)
+
+			diags := zoneLookupIDs(d, meta)
+			if tc.ExpectError != "" {
+				if !diags.HasError() || !strings.Contains(diags[0].Summary, tc.ExpectError) {
+					t.Fatalf("expected an error containing %q, got %#v", tc.ExpectError, diags)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("did not expect an error but one was raised: %#v", diags)
+			}
+
+			if got := d.Get("admin_group_id").(string); got != tc.Expect {
+				t.Fatalf("expected admin_group_id to be %q, got %q", tc.Expect, got)
+			}
+		})
+	}
+}
+
+func TestResourceVinylDNSZoneDiffAdminGroupName(t *testing.T) {
+	cases := map[string]struct {
+		Config      map[string]interface{}
+		Expect      string
+		ExpectError string
+	}{
+		"ID": {
+			Config: map[string]interface{}{"admin_group_id": "other-group-id"},
+			Expect: "other-group-id",
+		},
+		"name": {
+			Config: map[string]interface{}{"admin_group_name": "dns-admins"},
+			Expect: "group-id",
+		},
+		"no such group": {
+			Config:      map[string]interface{}{"admin_group_name": "dns"},
+			ExpectError: `admin_group_name: no group named "dns" found`,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			meta := testAPIServerRoutes(t, testLookupRoutes)
+
+			config := map[string]interface{}{"name": "example.com.", "email": "admin@example.com"}
+			for k, v := range tc.Config {
+				config[k] = v
+			}
+
+			state := &terraform.InstanceState{RawConfig: testRawConfig(t, resourceVinylDNSZone(), config)}
+
+			diff, err := resourceVinylDNSZone().Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+			if tc.ExpectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.ExpectError) {
+					t.Fatalf("expected an error containing %q, got %v", tc.ExpectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect an error but one was raised: %s", err)
+			}
+
+			if attr := diff.Attributes["admin_group_id"]; attr == nil || attr.New != tc.Expect {
+				t.Fatalf("expected admin_group_id to be planned as %q, got %#v", tc.Expect, attr)
+			}
+		})
+	}
+}
+
+func TestSuppressZoneNameDiff(t *testing.T) {
+	cases := map[string]struct {
+		Old      string
+		New      string
+		Expected bool
+	}{
+		"equal":         {Old: "example.com.", New: "example.com.", Expected: true},
+		"trailing dot":  {Old: "example.com.", New: "example.com", Expected: true},
+		"case":          {Old: "example.com.", New: "Example.COM.", Expected: true},
+		"different":     {Old: "example.com.", New: "example.org.", Expected: false},
+		"sub zone":      {Old: "example.com.", New: "sub.example.com.", Expected: false},
+		"new from none": {Old: "", New: "example.com.", Expected: false},
+		"unicode":       {Old: "xn--bcher-kva.example.", New: "bücher.example", Expected: true},
+		"unicode case":  {Old: "xn--bcher-kva.example.", New: "BÜCHER.example.", Expected: true},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			if got := suppressZoneNameDiff("zone_name", tc.Old, tc.New, nil); got != tc.Expected {
+				t.Fatalf("expected %t, got %t", tc.Expected, got)
+			}
+		})
+	}
+}
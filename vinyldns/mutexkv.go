@@ -0,0 +1,75 @@
+/*
+Copyright 2018 Comcast Cable Communications Management, LLC
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vinyldns
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// mutexKV is a set of locks keyed by string, used to serialize changes
+// that VinylDNS would otherwise reject as conflicting, such as concurrent
+// record set changes within one zone. Each lock is a channel with room for
+// one holder, so that waiting for it can be abandoned.
+type mutexKV struct {
+	lock  sync.Mutex
+	store map[string]chan struct{}
+}
+
+func newMutexKV() *mutexKV {
+	return &mutexKV{
+		store: make(map[string]chan struct{}),
+	}
+}
+
+// Lock locks key, blocking until it is available, ctx is cancelled or the
+// deadline passes. It only returns nil once the lock is held.
+func (m *mutexKV) Lock(ctx context.Context, key string, deadline time.Time) error {
+	log.Printf("[DEBUG] Locking %q", key)
+
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+
+	select {
+	case m.get(key) <- struct{}{}:
+		log.Printf("[DEBUG] Locked %q", key)
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return fmt.Errorf("timed out waiting for the lock on %q", key)
+	}
+}
+
+// Unlock unlocks key, which must have been locked by Lock.
+func (m *mutexKV) Unlock(key string) {
+	log.Printf("[DEBUG] Unlocking %q", key)
+	<-m.get(key)
+	log.Printf("[DEBUG] Unlocked %q", key)
+}
+
+func (m *mutexKV) get(key string) chan struct{} {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	ch, ok := m.store[key]
+	if !ok {
+		ch = make(chan struct{}, 1)
+		m.store[key] = ch
+	}
+
+	return ch
+}
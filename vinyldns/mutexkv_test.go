@@ -0,0 +1,129 @@
+/*
+Copyright 2018 Comcast Cable Communications Management, LLC
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vinyldns
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMutexKVSerializesSameKey(t *testing.T) {
+	m := newMutexKV()
+
+	var mu sync.Mutex
+	active, maxActive := 0, 0
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := m.Lock(context.Background(), "zone-id", time.Now().Add(time.Minute)); err != nil {
+				t.Errorf("did not expect an error but one was raised: %s", err)
+				return
+			}
+			defer m.Unlock("zone-id")
+
+			mu.Lock()
+			active++
+			if active > maxActive {
+				maxActive = active
+			}
+			mu.Unlock()
+
+			time.Sleep(time.Millisecond)
+
+			mu.Lock()
+			active--
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	if maxActive != 1 {
+		t.Fatalf("expected changes to one key to be serialized, got %d concurrent holders", maxActive)
+	}
+}
+
+func TestMutexKVDifferentKeys(t *testing.T) {
+	m := newMutexKV()
+	if err := m.Lock(context.Background(), "zone-1", time.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("did not expect an error but one was raised: %s", err)
+	}
+	defer m.Unlock("zone-1")
+
+	done := make(chan struct{})
+	go func() {
+		if err := m.Lock(context.Background(), "zone-2", time.Now().Add(time.Minute)); err == nil {
+			m.Unlock("zone-2")
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("expected a lock on a different key not to block")
+	}
+}
+
+// A change queued behind others in its zone must still stop when it is
+// cancelled or its timeout passes.
+func TestMutexKVWaitEnds(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+
+	cases := map[string]struct {
+		Ctx      context.Context
+		Deadline time.Time
+		Cancel   func()
+		Expected string
+	}{
+		"cancelled": {
+			Ctx:      cancelled,
+			Deadline: time.Now().Add(time.Minute),
+			Cancel:   cancel,
+			Expected: context.Canceled.Error(),
+		},
+		"deadline passed": {
+			Ctx:      context.Background(),
+			Deadline: time.Now().Add(50 * time.Millisecond),
+			Cancel:   func() {},
+			Expected: `timed out waiting for the lock on "zone-id"`,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			m := newMutexKV()
+			if err := m.Lock(context.Background(), "zone-id", time.Now().Add(time.Minute)); err != nil {
+				t.Fatalf("did not expect an error but one was raised: %s", err)
+			}
+			defer m.Unlock("zone-id")
+
+			errs := make(chan error, 1)
+			go func() { errs <- m.Lock(tc.Ctx, "zone-id", tc.Deadline) }()
+			tc.Cancel()
+
+			select {
+			case err := <-errs:
+				if err == nil || err.Error() != tc.Expected {
+					t.Fatalf("expected %q, got %v", tc.Expected, err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("expected the blocked waiter to be released")
+			}
+		})
+	}
+}
@@ -0,0 +1,258 @@
+/*
+Copyright 2018 Comcast Cable Communications Management, LLC
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vinyldns
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	defaultPollInitialInterval = 500 * time.Millisecond
+	defaultPollMaxInterval     = 15 * time.Second
+	defaultPollMultiplier      = 2.0
+	defaultPollJitter          = 0.2
+)
+
+// pollingStrategy describes how often change status is polled while
+// waiting on VinylDNS to apply a zone or record set change. The interval
+// starts at InitialInterval, grows by Multiplier after each poll, and is
+// capped at MaxInterval. Jitter randomizes each interval by up to that
+// fraction in either direction so that parallel waiters spread out.
+type pollingStrategy struct {
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	Multiplier      float64
+	Jitter          float64
+
+	// rand returns a value in [0, 1); it is only overridden by tests.
+	rand func() float64
+}
+
+func defaultPollingStrategy() pollingStrategy {
+	return pollingStrategy{
+		InitialInterval: defaultPollInitialInterval,
+		MaxInterval:     defaultPollMaxInterval,
+		Multiplier:      defaultPollMultiplier,
+		Jitter:          defaultPollJitter,
+	}
+}
+
+// interval returns how long to wait before the given (zero-based) poll.
+func (p pollingStrategy) interval(attempt int) time.Duration {
+	base := float64(p.InitialInterval) * math.Pow(p.Multiplier, float64(attempt))
+	if base > float64(p.MaxInterval) {
+		base = float64(p.MaxInterval)
+	}
+
+	if p.Jitter > 0 {
+		r := rand.Float64
+		if p.rand != nil {
+			r = p.rand
+		}
+		base += base * p.Jitter * (2*r() - 1)
+	}
+
+	return time.Duration(base)
+}
+
+// schedule returns the first n polling intervals.
+func (p pollingStrategy) schedule(n int) []time.Duration {
+	intervals := make([]time.Duration, 0, n)
+	for i := 0; i < n; i++ {
+		intervals = append(intervals, p.interval(i))
+	}
+
+	return intervals
+}
+
+// pollingSchema returns the schema for a block configuring a
+// pollingStrategy, defaulting to the values in defaults.
+func pollingSchema(defaults pollingStrategy) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"initial_interval": &schema.Schema{
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      defaults.InitialInterval.String(),
+					ValidateFunc: validateDuration,
+				},
+				"max_interval": &schema.Schema{
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      defaults.MaxInterval.String(),
+					ValidateFunc: validateDuration,
+				},
+				"multiplier": &schema.Schema{
+					Type:         schema.TypeFloat,
+					Optional:     true,
+					Default:      defaults.Multiplier,
+					ValidateFunc: validation.FloatAtLeast(1),
+				},
+				"jitter": &schema.Schema{
+					Type:         schema.TypeFloat,
+					Optional:     true,
+					Default:      defaults.Jitter,
+					ValidateFunc: validation.FloatBetween(0, 1),
+				},
+			},
+		},
+	}
+}
+
+// pollingStrategyFromConfig reads the pollingSchema block at key, falling
+// back to defaults when the block is absent.
+func pollingStrategyFromConfig(d *schema.ResourceData, key string, defaults pollingStrategy) (pollingStrategy, error) {
+	p := defaults
+
+	raw := d.Get(key).([]interface{})
+	if len(raw) == 0 || raw[0] == nil {
+		return p, nil
+	}
+
+	cfg := raw[0].(map[string]interface{})
+
+	initial, err := time.ParseDuration(cfg["initial_interval"].(string))
+	if err != nil {
+		return p, fmt.Errorf("%s.0.initial_interval: %s", key, err)
+	}
+
+	max, err := time.ParseDuration(cfg["max_interval"].(string))
+	if err != nil {
+		return p, fmt.Errorf("%s.0.max_interval: %s", key, err)
+	}
+
+	if max < initial {
+		return p, fmt.Errorf("%s.0.max_interval (%s) must not be less than %s.0.initial_interval (%s)", key, max, key, initial)
+	}
+
+	p.InitialInterval = initial
+	p.MaxInterval = max
+	p.Multiplier = cfg["multiplier"].(float64)
+	p.Jitter = cfg["jitter"].(float64)
+
+	return p, nil
+}
+
+// changeWaiter polls Refresh until it reports one of the Target states,
+// spacing the requests according to Polling. It follows the semantics of
+// resource.StateChangeConf so the refresh funcs can be shared between them.
+type changeWaiter struct {
+	Pending []string
+	Target  []string
+	Refresh resource.StateRefreshFunc
+	Timeout time.Duration
+	Polling pollingStrategy
+
+	// NotFoundChecks is the number of consecutive nil results allowed
+	// before giving up. Defaults to 20.
+	NotFoundChecks int
+}
+
+// WaitForStateContext returns ctx's error as soon as ctx is cancelled, so
+// that interrupting Terraform stops the wait.
+func (w *changeWaiter) WaitForStateContext(ctx context.Context) (interface{}, error) {
+	log.Printf("[DEBUG] Waiting for state to become: %s", w.Target)
+
+	notFoundChecks := w.NotFoundChecks
+	if notFoundChecks == 0 {
+		notFoundChecks = 20
+	}
+
+	deadline := time.Now().Add(w.Timeout)
+	notFound := 0
+	lastState := ""
+
+	for attempt := 0; ; attempt++ {
+		wait := w.Polling.interval(attempt)
+		if remaining := time.Until(deadline); wait > remaining {
+			if remaining <= 0 {
+				return nil, &resource.TimeoutError{
+					LastState:     lastState,
+					Timeout:       w.Timeout,
+					ExpectedState: w.Target,
+				}
+			}
+			wait = remaining
+		}
+
+		log.Printf("[TRACE] Waiting %s before next try", wait)
+		if err := sleepContext(ctx, wait); err != nil {
+			return nil, err
+		}
+
+		res, state, err := w.Refresh()
+		if err != nil {
+			return res, err
+		}
+		lastState = state
+
+		if res == nil {
+			notFound++
+			if notFound > notFoundChecks {
+				return nil, &resource.NotFoundError{
+					Retries: notFound,
+				}
+			}
+			continue
+		}
+		notFound = 0
+
+		if stringInSlice(state, w.Target) {
+			return res, nil
+		}
+
+		if !stringInSlice(state, w.Pending) {
+			return res, &resource.UnexpectedStateError{
+				State:         state,
+				ExpectedState: w.Target,
+			}
+		}
+	}
+}
+
+// sleepContext waits for d, returning early with ctx's error if ctx is
+// cancelled first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func stringInSlice(s string, list []string) bool {
+	for _, v := range list {
+		if s == v {
+			return true
+		}
+	}
+
+	return false
+}
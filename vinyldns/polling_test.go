@@ -0,0 +1,313 @@
+/*
+Copyright 2018 Comcast Cable Communications Management, LLC
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vinyldns
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestPollingStrategySchedule(t *testing.T) {
+	cases := map[string]struct {
+		Strategy pollingStrategy
+		Expected []time.Duration
+	}{
+		"default without jitter": {
+			Strategy: pollingStrategy{
+				InitialInterval: 500 * time.Millisecond,
+				MaxInterval:     15 * time.Second,
+				Multiplier:      2,
+			},
+			Expected: []time.Duration{
+				500 * time.Millisecond,
+				1 * time.Second,
+				2 * time.Second,
+				4 * time.Second,
+				8 * time.Second,
+				15 * time.Second,
+				15 * time.Second,
+			},
+		},
+		"constant": {
+			Strategy: pollingStrategy{
+				InitialInterval: 5 * time.Second,
+				MaxInterval:     5 * time.Second,
+				Multiplier:      1,
+			},
+			Expected: []time.Duration{
+				5 * time.Second,
+				5 * time.Second,
+				5 * time.Second,
+			},
+		},
+		"fractional multiplier": {
+			Strategy: pollingStrategy{
+				InitialInterval: 1 * time.Second,
+				MaxInterval:     3 * time.Second,
+				Multiplier:      1.5,
+			},
+			Expected: []time.Duration{
+				1 * time.Second,
+				1500 * time.Millisecond,
+				2250 * time.Millisecond,
+				3 * time.Second,
+			},
+		},
+		"maximum jitter upwards": {
+			Strategy: pollingStrategy{
+				InitialInterval: 1 * time.Second,
+				MaxInterval:     4 * time.Second,
+				Multiplier:      2,
+				Jitter:          0.5,
+				rand:            func() float64 { return 1 },
+			},
+			Expected: []time.Duration{
+				1500 * time.Millisecond,
+				3 * time.Second,
+				6 * time.Second,
+			},
+		},
+		"maximum jitter downwards": {
+			Strategy: pollingStrategy{
+				InitialInterval: 1 * time.Second,
+				MaxInterval:     4 * time.Second,
+				Multiplier:      2,
+				Jitter:          0.5,
+				rand:            func() float64 { return 0 },
+			},
+			Expected: []time.Duration{
+				500 * time.Millisecond,
+				1 * time.Second,
+				2 * time.Second,
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got := tc.Strategy.schedule(len(tc.Expected))
+			if !reflect.DeepEqual(got, tc.Expected) {
+				t.Fatalf("expected schedule %v, got %v", tc.Expected, got)
+			}
+		})
+	}
+}
+
+func TestPollingStrategyJitterBounds(t *testing.T) {
+	p := defaultPollingStrategy()
+
+	for i := 0; i < 1000; i++ {
+		attempt := i % 10
+		p.Jitter = 0
+		base := p.interval(attempt)
+		p.Jitter = defaultPollJitter
+		got := p.interval(attempt)
+
+		low := time.Duration(float64(base) * (1 - defaultPollJitter))
+		high := time.Duration(float64(base) * (1 + defaultPollJitter))
+		if got < low || got > high {
+			t.Fatalf("attempt %d: expected interval within [%s, %s], got %s", attempt, low, high, got)
+		}
+	}
+}
+
+func TestPollingStrategyFromConfig(t *testing.T) {
+	cases := map[string]struct {
+		Raw         map[string]interface{}
+		Expected    pollingStrategy
+		ExpectError bool
+	}{
+		"unset": {
+			Raw:      map[string]interface{}{},
+			Expected: defaultPollingStrategy(),
+		},
+		"partial": {
+			Raw: map[string]interface{}{
+				"polling": []interface{}{
+					map[string]interface{}{
+						"initial_interval": "1s",
+						"jitter":           0.0,
+					},
+				},
+			},
+			Expected: pollingStrategy{
+				InitialInterval: 1 * time.Second,
+				MaxInterval:     defaultPollMaxInterval,
+				Multiplier:      defaultPollMultiplier,
+				Jitter:          0,
+			},
+		},
+		"max below initial": {
+			Raw: map[string]interface{}{
+				"polling": []interface{}{
+					map[string]interface{}{
+						"initial_interval": "30s",
+						"max_interval":     "10s",
+					},
+				},
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, Provider().Schema, tc.Raw)
+			got, err := pollingStrategyFromConfig(d, "polling", defaultPollingStrategy())
+			if tc.ExpectError {
+				if err == nil {
+					t.Fatalf("expected an error but one was not raised")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect an error but one was raised: %s", err)
+			}
+			if !reflect.DeepEqual(got, tc.Expected) {
+				t.Fatalf("expected %#v, got %#v", tc.Expected, got)
+			}
+		})
+	}
+}
+
+func testFastPolling() pollingStrategy {
+	return pollingStrategy{
+		InitialInterval: time.Millisecond,
+		MaxInterval:     time.Millisecond,
+		Multiplier:      1,
+	}
+}
+
+func TestChangeWaiter(t *testing.T) {
+	states := []string{"", "Pending", "Pending", "Complete"}
+	calls := 0
+
+	w := &changeWaiter{
+		Pending: []string{"Pending", ""},
+		Target:  []string{"Complete"},
+		Refresh: func() (interface{}, string, error) {
+			state := states[calls]
+			calls++
+			return state, state, nil
+		},
+		Timeout: time.Second,
+		Polling: testFastPolling(),
+	}
+
+	res, err := w.WaitForStateContext(context.Background())
+	if err != nil {
+		t.Fatalf("did not expect an error but one was raised: %s", err)
+	}
+	if res != "Complete" {
+		t.Fatalf("expected result to be Complete, got %v", res)
+	}
+	if calls != len(states) {
+		t.Fatalf("expected %d refreshes, got %d", len(states), calls)
+	}
+}
+
+func TestChangeWaiterErrors(t *testing.T) {
+	refreshErr := errors.New("boom")
+
+	cases := map[string]struct {
+		Refresh resource.StateRefreshFunc
+		Check   func(error) bool
+	}{
+		"refresh error": {
+			Refresh: func() (interface{}, string, error) {
+				return nil, "", refreshErr
+			},
+			Check: func(err error) bool { return err == refreshErr },
+		},
+		"unexpected state": {
+			Refresh: func() (interface{}, string, error) {
+				return "Failed", "Failed", nil
+			},
+			Check: func(err error) bool {
+				_, ok := err.(*resource.UnexpectedStateError)
+				return ok
+			},
+		},
+		"timeout": {
+			Refresh: func() (interface{}, string, error) {
+				return "Pending", "Pending", nil
+			},
+			Check: func(err error) bool {
+				_, ok := err.(*resource.TimeoutError)
+				return ok
+			},
+		},
+		"not found": {
+			Refresh: func() (interface{}, string, error) {
+				return nil, "", nil
+			},
+			Check: func(err error) bool {
+				_, ok := err.(*resource.NotFoundError)
+				return ok
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			w := &changeWaiter{
+				Pending: []string{"Pending"},
+				Target:  []string{"Complete"},
+				Refresh: tc.Refresh,
+				Timeout: 50 * time.Millisecond,
+				Polling: testFastPolling(),
+
+				NotFoundChecks: 2,
+			}
+
+			_, err := w.WaitForStateContext(context.Background())
+			if !tc.Check(err) {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+		})
+	}
+}
+
+func TestChangeWaiterCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	w := &changeWaiter{
+		Pending: []string{"Pending"},
+		Target:  []string{"Complete"},
+		Refresh: func() (interface{}, string, error) {
+			cancel()
+			return "Pending", "Pending", nil
+		},
+		Timeout: time.Minute,
+		Polling: pollingStrategy{
+			InitialInterval: time.Millisecond,
+			MaxInterval:     time.Minute,
+			Multiplier:      60000,
+		},
+	}
+
+	start := time.Now()
+	_, err := w.WaitForStateContext(ctx)
+	if err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got %#v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("expected the wait to stop when cancelled, took %s", elapsed)
+	}
+}
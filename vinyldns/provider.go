@@ -13,9 +13,15 @@ limitations under the License.
 package vinyldns
 
 import (
+	"context"
+	"net/http"
 	"os"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vinyldns/go-vinyldns/vinyldns"
 )
 
@@ -25,12 +31,12 @@ func Provider() *schema.Provider {
 		Schema: map[string]*schema.Schema{
 			"access_key": &schema.Schema{
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: envDefaultFunc("VINYLDNS_ACCESS_KEY"),
 			},
 			"secret_key": &schema.Schema{
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: envDefaultFunc("VINYLDNS_SECRET_KEY"),
 			},
 			"host": &schema.Schema{
@@ -38,6 +44,78 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				DefaultFunc: envDefaultFunc("VINYLDNS_HOST"),
 			},
+			"credential_process": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: envDefaultFunc("VINYLDNS_CREDENTIAL_PROCESS"),
+			},
+			"profile": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: envDefaultFunc("VINYLDNS_PROFILE"),
+			},
+			"shared_credentials_file": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: envDefaultFunc("VINYLDNS_SHARED_CREDENTIALS_FILE"),
+			},
+			// VINYLDNS_CA_FILE is applied in transportConfigFromResourceData,
+			// so that an explicit ca_pem takes precedence over it.
+			"ca_file": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_pem"},
+			},
+			"ca_pem": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_file"},
+			},
+			"client_cert": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  envDefaultFunc("VINYLDNS_CLIENT_CERT"),
+				RequiredWith: []string{"client_key"},
+			},
+			"client_key": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				DefaultFunc:  envDefaultFunc("VINYLDNS_CLIENT_KEY"),
+				RequiredWith: []string{"client_cert"},
+			},
+			"insecure_skip_verify": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"proxy_url": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: envDefaultFunc("VINYLDNS_PROXY_URL"),
+			},
+			"polling": pollingSchema(defaultPollingStrategy()),
+			"max_retries": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultMaxRetries,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_backoff": pollingSchema(defaultRetryBackoff()),
+			"skip_credentials_validation": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"default_ttl": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(minRecordSetTTL, maxRecordSetTTL),
+			},
+			"default_owner_group_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
@@ -55,7 +133,7 @@ func Provider() *schema.Provider {
 			"vinyldns_backend_ids": dataSourceVinylDNSBackendIDs(),
 		},
 
-		ConfigureFunc: providerConfigure,
+		ConfigureContextFunc: providerConfigure,
 	}
 }
 
@@ -76,13 +154,101 @@ func envDefaultFuncAllowMissing(k string) schema.SchemaDefaultFunc {
 	}
 }
 
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+// providerMeta is passed to every resource and data source as meta.
+type providerMeta struct {
+	client  *vinyldns.Client
+	polling pollingStrategy
+
+	// zoneLocks serializes record set changes within a zone.
+	zoneLocks *mutexKV
+
+	// defaultTTL and defaultOwnerGroupID are used for record sets that do
+	// not set ttl or owner_group_id. Zero values mean no default.
+	defaultTTL          int
+	defaultOwnerGroupID string
+}
+
+// clientWithTimeout returns a copy of c whose requests, including any
+// retries and the waits between them, are abandoned after timeout. The
+// go-vinyldns client's calls take no context, so this is how an operation's
+// timeout bounds them.
+func clientWithTimeout(c *vinyldns.Client, timeout time.Duration) *vinyldns.Client {
+	timed := *c
+	httpClient := *c.HTTPClient
+	httpClient.Timeout = timeout
+	timed.HTTPClient = &httpClient
+
+	return &timed
+}
+
+func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	creds, err := resolveCredentials(credentialsConfigFromResourceData(d))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	var process *credentialProcess
+	if creds.CredentialProcess != "" {
+		process = newCredentialProcess(creds.CredentialProcess)
+
+		// fetch the first keys now so a broken command fails the
+		// configuration rather than the first request
+		initial, err := process.Retrieve(ctx)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		creds.AccessKey = initial.AccessKey
+		creds.SecretKey = initial.SecretKey
+	}
+
+	if creds.AccessKey == "" || creds.SecretKey == "" {
+		return nil, diag.Errorf("access_key and secret_key, or credential_process, must be set in the provider block, through the VINYLDNS_* environment variables, or in a shared credentials file profile")
+	}
+
 	config := vinyldns.ClientConfiguration{
-		AccessKey: d.Get("access_key").(string),
-		SecretKey: d.Get("secret_key").(string),
-		Host:      d.Get("host").(string),
+		AccessKey: creds.AccessKey,
+		SecretKey: creds.SecretKey,
+		Host:      creds.Host,
 		UserAgent: GetUserAgent(),
 	}
 
-	return vinyldns.NewClient(config), nil
+	polling, err := pollingStrategyFromConfig(d, "polling", defaultPollingStrategy())
+	if err != nil {
+		return nil, attributeError(cty.GetAttrPath("polling"), "%s", err)
+	}
+
+	backoff, err := pollingStrategyFromConfig(d, "retry_backoff", defaultRetryBackoff())
+	if err != nil {
+		return nil, attributeError(cty.GetAttrPath("retry_backoff"), "%s", err)
+	}
+
+	transport, err := newTransport(transportConfigFromResourceData(d))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	var next http.RoundTripper = transport
+	if process != nil {
+		next = newCredentialProcessTransport(transport, process)
+	}
+
+	client := vinyldns.NewClient(config)
+	client.HTTPClient = &http.Client{
+		Transport: newRetryTransport(next, d.Get("max_retries").(int), backoff),
+	}
+
+	if !d.Get("skip_credentials_validation").(bool) {
+		if diags := validateCredentials(client); diags.HasError() {
+			return nil, diags
+		}
+	}
+
+	return &providerMeta{
+		client:              client,
+		polling:             polling,
+		zoneLocks:           newMutexKV(),
+		defaultTTL:          d.Get("default_ttl").(int),
+		defaultOwnerGroupID: d.Get("default_owner_group_id").(string),
+	}, nil
 }
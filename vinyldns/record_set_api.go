@@ -0,0 +1,264 @@
+/*
+Copyright 2018 Comcast Cable Communications Management, LLC
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vinyldns
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/vinyldns/go-vinyldns/vinyldns"
+)
+
+// The go-vinyldns client's record set types have no fields for NAPTR or DS
+// record data, and carry the SSHFP fingerprint type as a string where
+// VinylDNS uses a number, so a record set holding any of these cannot be
+// sent or even read through them. Record sets are instead sent and read as
+// the types below, in requests signed the way the client signs them and sent
+// through its HTTP client, so they share the provider's transport, retries
+// and credentials.
+
+// recordSet is a VinylDNS record set.
+type recordSet struct {
+	ID           string   `json:"id,omitempty"`
+	ZoneID       string   `json:"zoneId"`
+	OwnerGroupID string   `json:"ownerGroupId,omitempty"`
+	Name         string   `json:"name,omitempty"`
+	Type         string   `json:"type"`
+	Status       string   `json:"status,omitempty"`
+	Created      string   `json:"created,omitempty"`
+	Updated      string   `json:"updated,omitempty"`
+	TTL          int      `json:"ttl"`
+	Account      string   `json:"account"`
+	Records      []record `json:"records"`
+	FQDN         string   `json:"fqdn,omitempty"`
+	ZoneName     string   `json:"zoneName,omitempty"`
+}
+
+// record is the data of one record in a record set. The fields where zero
+// or an empty string is a valid value, such as an SRV weight or a NAPTR
+// regexp, are pointers so that it is still sent.
+type record struct {
+	Address     string  `json:"address,omitempty"`
+	CName       string  `json:"cname,omitempty"`
+	Preference  *int    `json:"preference,omitempty"`
+	Exchange    string  `json:"exchange,omitempty"`
+	NSDName     string  `json:"nsdname,omitempty"`
+	PTRDName    string  `json:"ptrdname,omitempty"`
+	Text        string  `json:"text,omitempty"`
+	Priority    *int    `json:"priority,omitempty"`
+	Weight      *int    `json:"weight,omitempty"`
+	Port        *int    `json:"port,omitempty"`
+	Target      string  `json:"target,omitempty"`
+	Algorithm   int     `json:"algorithm,omitempty"`
+	Type        int     `json:"type,omitempty"`
+	Fingerprint string  `json:"fingerprint,omitempty"`
+	Order       *int    `json:"order,omitempty"`
+	Flags       *string `json:"flags,omitempty"`
+	Service     *string `json:"service,omitempty"`
+	Regexp      *string `json:"regexp,omitempty"`
+	Replacement string  `json:"replacement,omitempty"`
+	KeyTag      *int    `json:"keytag,omitempty"`
+	DigestType  int     `json:"digesttype,omitempty"`
+	Digest      string  `json:"digest,omitempty"`
+}
+
+// recordSetUpdateResponse is the response to creating, updating or deleting
+// a record set.
+type recordSetUpdateResponse struct {
+	RecordSet recordSet `json:"recordSet"`
+	ChangeID  string    `json:"id"`
+	Status    string    `json:"status"`
+}
+
+// recordSetChange is a change to a record set, as polled for its status.
+type recordSetChange struct {
+	Zone          vinyldns.Zone `json:"zone"`
+	RecordSet     recordSet     `json:"recordSet"`
+	ChangeType    string        `json:"changeType"`
+	Status        string        `json:"status"`
+	SystemMessage string        `json:"systemMessage,omitempty"`
+	ID            string        `json:"id"`
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func intValue(i *int) int {
+	if i == nil {
+		return 0
+	}
+
+	return *i
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
+func recordSetsURL(c *vinyldns.Client, zoneID string) string {
+	return c.Host + "/zones/" + zoneID + "/recordsets"
+}
+
+func recordSetURL(c *vinyldns.Client, zoneID, recordSetID string) string {
+	return recordSetsURL(c, zoneID) + "/" + recordSetID
+}
+
+func getRecordSet(ctx context.Context, c *vinyldns.Client, zoneID, recordSetID string) (recordSet, error) {
+	var resp struct {
+		RecordSet recordSet `json:"recordSet"`
+	}
+
+	err := recordSetRequest(ctx, c, http.MethodGet, recordSetURL(c, zoneID, recordSetID), nil, &resp)
+
+	return resp.RecordSet, err
+}
+
+func createRecordSet(ctx context.Context, c *vinyldns.Client, rs *recordSet) (*recordSetUpdateResponse, error) {
+	resp := &recordSetUpdateResponse{}
+	if err := recordSetRequest(ctx, c, http.MethodPost, recordSetsURL(c, rs.ZoneID), rs, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func updateRecordSet(ctx context.Context, c *vinyldns.Client, rs *recordSet) (*recordSetUpdateResponse, error) {
+	resp := &recordSetUpdateResponse{}
+	if err := recordSetRequest(ctx, c, http.MethodPut, recordSetURL(c, rs.ZoneID, rs.ID), rs, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func deleteRecordSet(ctx context.Context, c *vinyldns.Client, zoneID, recordSetID string) (*recordSetUpdateResponse, error) {
+	resp := &recordSetUpdateResponse{}
+	if err := recordSetRequest(ctx, c, http.MethodDelete, recordSetURL(c, zoneID, recordSetID), nil, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func getRecordSetChange(ctx context.Context, c *vinyldns.Client, zoneID, recordSetID, changeID string) (*recordSetChange, error) {
+	resp := &recordSetChange{}
+	if err := recordSetRequest(ctx, c, http.MethodGet, recordSetURL(c, zoneID, recordSetID)+"/changes/"+changeID, nil, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// listRecordSets lists the record sets in a zone whose names match
+// nameFilter, following the pages of results.
+func listRecordSets(ctx context.Context, c *vinyldns.Client, zoneID, nameFilter string) ([]recordSet, error) {
+	rss := []recordSet{}
+	startFrom := ""
+
+	for {
+		query := url.Values{}
+		if nameFilter != "" {
+			query.Set("recordNameFilter", nameFilter)
+		}
+		if startFrom != "" {
+			query.Set("startFrom", startFrom)
+		}
+		query.Set("maxItems", "100")
+
+		var resp struct {
+			RecordSets []recordSet `json:"recordSets"`
+			NextID     string      `json:"nextId,omitempty"`
+		}
+		if err := recordSetRequest(ctx, c, http.MethodGet, recordSetsURL(c, zoneID)+"?"+query.Encode(), nil, &resp); err != nil {
+			return nil, err
+		}
+
+		rss = append(rss, resp.RecordSets...)
+		if resp.NextID == "" {
+			return rss, nil
+		}
+		startFrom = resp.NextID
+	}
+}
+
+// recordSetRequest sends a signed request to the VinylDNS API and decodes
+// the response into out. A response other than 200, 201 or 202 is returned
+// as a *vinyldns.Error, as the client returns it.
+func recordSetRequest(ctx context.Context, c *vinyldns.Client, method, u string, in, out interface{}) error {
+	var body []byte
+	if in != nil {
+		var err error
+		body, err = json.Marshal(in)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("User-Agent", c.UserAgent)
+	req.Header.Set("Content-Type", "application/json")
+
+	h := sha256.Sum256(body)
+	err = v4.NewSigner().SignHTTP(ctx, aws.Credentials{
+		AccessKeyID:     c.AccessKey,
+		SecretAccessKey: c.SecretKey,
+	}, req, hex.EncodeToString(h[:]), "VinylDNS", "us-east-1", time.Now())
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	contents, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted {
+		return &vinyldns.Error{
+			RequestURL:    u,
+			RequestMethod: method,
+			RequestBody:   string(body),
+			ResponseCode:  resp.StatusCode,
+			ResponseBody:  string(contents),
+		}
+	}
+
+	return json.Unmarshal(contents, out)
+}
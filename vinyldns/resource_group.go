@@ -13,10 +13,13 @@ limitations under the License.
 package vinyldns
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vinyldns/go-vinyldns/vinyldns"
 )
@@ -24,12 +27,18 @@ import (
 func resourceVinylDNSGroup() *schema.Resource {
 	return &schema.Resource{
 		SchemaVersion: 1,
-		Create:        resourceVinylDNSGroupCreate,
-		Read:          resourceVinylDNSGroupRead,
-		Update:        resourceVinylDNSGroupUpdate,
-		Delete:        resourceVinylDNSGroupDelete,
+		CreateContext: resourceVinylDNSGroupCreate,
+		ReadContext:   resourceVinylDNSGroupRead,
+		UpdateContext: resourceVinylDNSGroupUpdate,
+		DeleteContext: resourceVinylDNSGroupDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: resourceVinylDNSGroupImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -62,10 +71,11 @@ func resourceVinylDNSGroup() *schema.Resource {
 	}
 }
 
-func resourceVinylDNSGroupCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceVinylDNSGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	name := d.Get("name").(string)
 	log.Printf("[INFO] Creating Group: %s", name)
-	created, err := meta.(*vinyldns.Client).GroupCreate(&vinyldns.Group{
+	client := clientWithTimeout(meta.(*providerMeta).client, d.Timeout(schema.TimeoutCreate))
+	created, err := client.GroupCreate(&vinyldns.Group{
 		Name:        d.Get("name").(string),
 		Email:       d.Get("email").(string),
 		Description: d.Get("description").(string),
@@ -73,17 +83,17 @@ func resourceVinylDNSGroupCreate(d *schema.ResourceData, meta interface{}) error
 		Admins:      users("admin_ids", d),
 	})
 	if err != nil {
-		return err
+		return errorDiagnostics(fmt.Sprintf("creating group (%s)", name), err)
 	}
 
 	d.SetId(created.ID)
 
-	return resourceVinylDNSGroupRead(d, meta)
+	return resourceVinylDNSGroupRead(ctx, d, meta)
 }
 
-func resourceVinylDNSGroupRead(d *schema.ResourceData, meta interface{}) error {
+func resourceVinylDNSGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[INFO] Reading vinyldns group: %s", d.Id())
-	g, err := meta.(*vinyldns.Client).Group(d.Id())
+	g, err := meta.(*providerMeta).client.Group(d.Id())
 	if err != nil {
 		if vErr, ok := err.(*vinyldns.Error); ok {
 			if vErr.ResponseCode == http.StatusNotFound {
@@ -94,10 +104,10 @@ func resourceVinylDNSGroupRead(d *schema.ResourceData, meta interface{}) error {
 				return nil
 			}
 
-			return fmt.Errorf("error reading group (%s): %s", d.Id(), err)
+			return errorDiagnostics(fmt.Sprintf("reading group (%s)", d.Id()), err)
 		}
 
-		return fmt.Errorf("error reading group (%s): %s", d.Id(), err)
+		return errorDiagnostics(fmt.Sprintf("reading group (%s)", d.Id()), err)
 	}
 
 	d.Set("name", g.Name)
@@ -110,7 +120,7 @@ func resourceVinylDNSGroupRead(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	if err := d.Set("member_ids", schema.NewSet(schema.HashString, memIDs)); err != nil {
-		return fmt.Errorf("error setting member_ids for group %s: %s", d.Id(), err)
+		return diag.Errorf("error setting member_ids for group %s: %s", d.Id(), err)
 	}
 
 	adminIDs := make([]interface{}, 0, len(g.Admins))
@@ -119,15 +129,16 @@ func resourceVinylDNSGroupRead(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	if err := d.Set("admin_ids", schema.NewSet(schema.HashString, adminIDs)); err != nil {
-		return fmt.Errorf("error setting admin_ids for group %s: %s", d.Id(), err)
+		return diag.Errorf("error setting admin_ids for group %s: %s", d.Id(), err)
 	}
 
 	return nil
 }
 
-func resourceVinylDNSGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceVinylDNSGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[INFO] Updating vinyldns group: %s", d.Id())
-	_, err := meta.(*vinyldns.Client).GroupUpdate(d.Id(), &vinyldns.Group{
+	client := clientWithTimeout(meta.(*providerMeta).client, d.Timeout(schema.TimeoutUpdate))
+	_, err := client.GroupUpdate(d.Id(), &vinyldns.Group{
 		ID:          d.Id(),
 		Name:        d.Get("name").(string),
 		Email:       d.Get("email").(string),
@@ -136,16 +147,17 @@ func resourceVinylDNSGroupUpdate(d *schema.ResourceData, meta interface{}) error
 		Admins:      users("admin_ids", d),
 	})
 	if err != nil {
-		return err
+		return errorDiagnostics(fmt.Sprintf("updating group (%s)", d.Id()), err)
 	}
 
-	return resourceVinylDNSGroupRead(d, meta)
+	return resourceVinylDNSGroupRead(ctx, d, meta)
 }
 
-func resourceVinylDNSGroupDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceVinylDNSGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[INFO] Deleting vinyldns group: %s", d.Id())
 
-	_, err := meta.(*vinyldns.Client).GroupDelete(d.Id())
+	client := clientWithTimeout(meta.(*providerMeta).client, d.Timeout(schema.TimeoutDelete))
+	_, err := client.GroupDelete(d.Id())
 	if err != nil {
 		if vErr, ok := err.(*vinyldns.Error); ok {
 			if vErr.ResponseCode == http.StatusNotFound {
@@ -154,10 +166,10 @@ func resourceVinylDNSGroupDelete(d *schema.ResourceData, meta interface{}) error
 				return nil
 			}
 
-			return fmt.Errorf("error deleting group (%s): %s", d.Id(), err)
+			return errorDiagnostics(fmt.Sprintf("deleting group (%s)", d.Id()), err)
 		}
 
-		return fmt.Errorf("error deleting group (%s): %s", d.Id(), err)
+		return errorDiagnostics(fmt.Sprintf("deleting group (%s)", d.Id()), err)
 	}
 
 	return nil
@@ -14,14 +14,45 @@ package vinyldns
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
-
-	"github.com/vinyldns/go-vinyldns/vinyldns"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/vinyldns/go-vinyldns/vinyldns"
 )
 
+// The go-vinyldns group calls take no context, so an operation's timeout is
+// applied to the client; it must also cut short a retry waiting out a long
+// Retry-After.
+func TestClientWithTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "20")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	client := vinyldns.NewClient(vinyldns.ClientConfiguration{
+		AccessKey: "accessKey",
+		SecretKey: "secretKey",
+		Host:      server.URL,
+	})
+	client.HTTPClient = &http.Client{Transport: newRetryTransport(nil, 3, defaultRetryBackoff())}
+
+	start := time.Now()
+	if _, err := clientWithTimeout(client, 100*time.Millisecond).Group("group-id"); err == nil {
+		t.Fatalf("expected a timeout error but one was not raised")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected the request to be abandoned after its timeout, took %s", elapsed)
+	}
+	if client.HTTPClient.Timeout != 0 {
+		t.Fatalf("expected the provider's client to be left without a timeout, got %s", client.HTTPClient.Timeout)
+	}
+}
+
 func TestAccVinylDNSGroupBasic(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -108,7 +139,7 @@ func testAccVinylDNSGroupImportStateCheck(s []*terraform.InstanceState) error {
 }
 
 func testAccVinylDNSGroupDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*vinyldns.Client)
+	client := testAccProvider.Meta().(*providerMeta).client
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "vinyldns_group" {
@@ -136,7 +167,7 @@ func testAccCheckVinylDNSGroupExists(n, desc string) resource.TestCheckFunc {
 			return fmt.Errorf("No Group ID is set")
 		}
 
-		client := testAccProvider.Meta().(*vinyldns.Client)
+		client := testAccProvider.Meta().(*providerMeta).client
 
 		g, err := client.Group(rs.Primary.ID)
 		if err != nil {
@@ -1,44 +1,146 @@
 package vinyldns
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
+	"math"
+	"net"
 	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vinyldns/go-vinyldns/vinyldns"
 )
 
+// VinylDNS rejects record sets with a TTL outside these bounds.
+const (
+	minRecordSetTTL = 30
+	maxRecordSetTTL = math.MaxInt32
+)
+
+// naptrFlagsRegexp matches NAPTR flags, single letters or digits such as
+// "U" or "S".
+var naptrFlagsRegexp = regexp.MustCompile(`^[A-Za-z0-9]*$`)
+
+// dsAlgorithms are the DNSSEC algorithm numbers VinylDNS accepts for DS
+// records.
+var dsAlgorithms = []int{3, 5, 6, 7, 8, 10, 12, 13, 14, 15, 16, 253, 254}
+
+// dsDigestLengths maps each DS digest type to the length of its digest in
+// hex digits: SHA-1, SHA-256, GOST R 34.11-94 and SHA-384.
+var dsDigestLengths = map[int]int{1: 40, 2: 64, 3: 64, 4: 96}
+
+// sshfpFingerprintLengths maps each SSHFP fingerprint type to the length of
+// its fingerprint in hex digits: SHA-1 and SHA-256.
+var sshfpFingerprintLengths = map[int]int{1: 40, 2: 64}
+
+// recordAttributes maps each record type the provider manages to the
+// attribute holding its records.
+var recordAttributes = map[string]string{
+	"a":     "record_addresses",
+	"aaaa":  "record_addresses",
+	"cname": "record_cname",
+	"ds":    "record_ds",
+	"mx":    "record_mx",
+	"naptr": "record_naptr",
+	"ns":    "record_nsdnames",
+	"ptr":   "record_ptrdnames",
+	"srv":   "record_srv",
+	"sshfp": "record_sshfp",
+	"txt":   "record_texts",
+}
+
 func resourceVinylDNSRecordSet() *schema.Resource {
 	return &schema.Resource{
 		SchemaVersion: 1,
-		Create:        resourceVinylDNSRecordSetCreate,
-		Read:          resourceVinylDNSRecordSetRead,
-		Update:        resourceVinylDNSRecordSetUpdate,
-		Delete:        resourceVinylDNSRecordSetDelete,
+		CreateContext: resourceVinylDNSRecordSetCreate,
+		ReadContext:   resourceVinylDNSRecordSetRead,
+		UpdateContext: resourceVinylDNSRecordSetUpdate,
+		DeleteContext: resourceVinylDNSRecordSetDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: resourceVinylDNSRecordSetImport,
+		},
+		MigrateState:  resourceVinylDNSRecordSetMigrateState,
+		CustomizeDiff: resourceVinylDNSRecordSetCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
 		},
-		MigrateState: resourceVinylDNSRecordSetMigrateState,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validateRecordName,
+				DiffSuppressFunc: suppressRecordNameDiff,
 			},
 			"zone_id": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"zone_id", "zone_name"},
+			},
+			"zone_name": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ExactlyOneOf:     []string{"zone_id", "zone_name"},
+				DiffSuppressFunc: suppressZoneNameDiff,
 			},
 			"owner_group_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"owner_group_name"},
+			},
+			"owner_group_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"owner_group_id"},
+			},
+			"name_ascii": {
 				Type:     schema.TypeString,
-				Optional: true,
+				Computed: true,
+			},
+			"name_unicode": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"fqdn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"created": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"updated": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"account": {
+				Type:     schema.TypeString,
+				Computed: true,
 			},
 			"type": {
 				Type:     schema.TypeString,
@@ -46,20 +148,22 @@ func resourceVinylDNSRecordSet() *schema.Resource {
 				ForceNew: true,
 			},
 			"ttl": {
-				Type:     schema.TypeInt,
-				Optional: true,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(minRecordSetTTL, maxRecordSetTTL),
 			},
 			"record_addresses": {
 				Type:     schema.TypeSet,
 				Optional: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
-				Set:      schema.HashString,
+				Set:      addressHash,
 			},
 			"record_texts": {
 				Type:     schema.TypeSet,
 				Optional: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
-				Set:      schema.HashString,
+				Set:      txtHash,
 			},
 			"record_nsdnames": {
 				Type:     schema.TypeSet,
@@ -77,46 +181,197 @@ func resourceVinylDNSRecordSet() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"record_mx": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"preference": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(0, math.MaxUint16),
+						},
+						"exchange": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateTrailingDot,
+						},
+					},
+				},
+			},
+			"record_srv": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"priority": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(0, math.MaxUint16),
+						},
+						"weight": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(0, math.MaxUint16),
+						},
+						"port": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(0, math.MaxUint16),
+						},
+						"target": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateTrailingDot,
+						},
+					},
+				},
+			},
+			"record_naptr": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"order": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(0, math.MaxUint16),
+						},
+						"preference": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(0, math.MaxUint16),
+						},
+						"flags": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringMatch(naptrFlagsRegexp, "must be made up of letters and digits"),
+						},
+						"service": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"regexp": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"replacement": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateTrailingDot,
+						},
+					},
+				},
+			},
+			"record_ds": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Set:      dsRecordHash,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key_tag": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(0, math.MaxUint16),
+						},
+						"algorithm": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntInSlice(dsAlgorithms),
+						},
+						"digest_type": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntInSlice([]int{1, 2, 3, 4}),
+						},
+						"digest": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateHexString,
+						},
+					},
+				},
+			},
+			"record_sshfp": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Set:      sshfpRecordHash,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"algorithm": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntInSlice([]int{1, 2, 3, 4, 6}),
+						},
+						"type": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntInSlice([]int{1, 2}),
+						},
+						"fingerprint": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateSSHFPFingerprint,
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
-func resourceVinylDNSRecordSetCreate(d *schema.ResourceData, meta interface{}) error {
-	name := d.Get("name").(string)
+func resourceVinylDNSRecordSetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := recordSetLookupIDs(d, meta); diags.HasError() {
+		return diags
+	}
+
+	name := recordSetName(d)
 	log.Printf("[INFO] Creating vinyldns record set: %s", name)
-	records, err := records(d)
-	if err != nil {
-		return err
+	records, diags := records(d)
+	if diags.HasError() {
+		return diags
+	}
+	zoneID := d.Get("zone_id").(string)
+	deadline := time.Now().Add(d.Timeout(schema.TimeoutCreate))
+	if err := meta.(*providerMeta).zoneLocks.Lock(ctx, zoneID, deadline); err != nil {
+		return errorDiagnostics(fmt.Sprintf("waiting for other changes in zone %s before creating recordset (%s)", zoneID, name), err)
 	}
-	created, err := meta.(*vinyldns.Client).RecordSetCreate(&vinyldns.RecordSet{
-		Name:         d.Get("name").(string),
-		ZoneID:       d.Get("zone_id").(string),
-		OwnerGroupID: d.Get("owner_group_id").(string),
-		Type:         d.Get("type").(string),
-		TTL:          d.Get("ttl").(int),
-		Records:      records,
+	defer meta.(*providerMeta).zoneLocks.Unlock(zoneID)
+
+	var created *recordSetUpdateResponse
+	err := retryOnPendingChange(ctx, meta, deadline, func() error {
+		var err error
+		created, err = createRecordSet(ctx, meta.(*providerMeta).client, &recordSet{
+			Name:         name,
+			ZoneID:       zoneID,
+			OwnerGroupID: d.Get("owner_group_id").(string),
+			Type:         d.Get("type").(string),
+			TTL:          d.Get("ttl").(int),
+			Records:      records,
+		})
+		return err
 	})
 	if err != nil {
-		return err
+		return errorDiagnostics(fmt.Sprintf("creating recordset (%s)", name), err)
 	}
 
 	d.SetId(created.RecordSet.ZoneID + ":" + created.RecordSet.ID)
 
-	err = waitUntilRecordSetDeployed(d, meta, created.ChangeID)
+	err = waitUntilRecordSetDeployed(ctx, d, meta, created.ChangeID, time.Until(deadline))
 	if err != nil {
-		return err
+		return errorDiagnostics(fmt.Sprintf("waiting for recordset (%s) to be created", name), err)
 	}
 
-	return resourceVinylDNSRecordSetRead(d, meta)
+	return resourceVinylDNSRecordSetRead(ctx, d, meta)
 }
 
-func resourceVinylDNSRecordSetRead(d *schema.ResourceData, meta interface{}) error {
+func resourceVinylDNSRecordSetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	zID, rsID, err := parseTwoPartID(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	log.Printf("[INFO] Reading vinyldns record set %s in zone %s", rsID, zID)
-	rs, err := meta.(*vinyldns.Client).RecordSet(zID, rsID)
+	rs, err := getRecordSet(ctx, meta.(*providerMeta).client, zID, rsID)
 	if err != nil {
 		if vErr, ok := err.(*vinyldns.Error); ok {
 			if vErr.ResponseCode == http.StatusNotFound {
@@ -127,26 +382,44 @@ func resourceVinylDNSRecordSetRead(d *schema.ResourceData, meta interface{}) err
 				return nil
 			}
 
-			return fmt.Errorf("error reading recordset (%s): %s", rsID, err)
+			return errorDiagnostics(fmt.Sprintf("reading recordset (%s)", rsID), err)
 		}
 
-		return fmt.Errorf("error reading recordset (%s): %s", rsID, err)
+		return errorDiagnostics(fmt.Sprintf("reading recordset (%s)", rsID), err)
 	}
 
 	recordType := strings.ToLower(rs.Type)
 
-	if recordType == "soa" {
-		return errors.New(recordType + " records are not currently supported by vinyldns")
+	if err := unsupportedRecordType(recordType); err != nil {
+		return attributeError(cty.GetAttrPath("type"), "%s", err)
 	}
 
 	d.Set("name", rs.Name)
+	d.Set("name_ascii", rs.Name)
+	d.Set("name_unicode", unicodeName(rs.Name))
 	d.Set("zone_id", rs.ZoneID)
+	d.Set("zone_name", rs.ZoneName)
 	d.Set("ttl", rs.TTL)
 	d.Set("type", rs.Type)
 	d.Set("owner_group_id", rs.OwnerGroupID)
+	d.Set("fqdn", rs.FQDN)
+	d.Set("status", rs.Status)
+	d.Set("created", rs.Created)
+	d.Set("updated", rs.Updated)
+	d.Set("account", rs.Account)
+
+	ownerGroupName, diags := groupName(meta, rs.OwnerGroupID)
+	if diags.HasError() {
+		return diags
+	}
+	d.Set("owner_group_name", ownerGroupName)
 
 	if recordType == "cname" {
-		d.Set("record_cname", rs.Records[0].CName)
+		cname := ""
+		if len(rs.Records) > 0 {
+			cname = rs.Records[0].CName
+		}
+		d.Set("record_cname", cname)
 
 		return nil
 	}
@@ -159,7 +432,7 @@ func resourceVinylDNSRecordSetRead(d *schema.ResourceData, meta interface{}) err
 		}
 
 		if err := d.Set("record_ptrdnames", schema.NewSet(schema.HashString, recs)); err != nil {
-			return fmt.Errorf("error setting record_ptrdnames for record set %s: %s", d.Id(), err)
+			return diag.Errorf("error setting record_ptrdnames for record set %s: %s", d.Id(), err)
 		}
 
 		return nil
@@ -169,7 +442,7 @@ func resourceVinylDNSRecordSetRead(d *schema.ResourceData, meta interface{}) err
 		recs := make([]interface{}, 0, len(rs.Records))
 
 		for _, r := range rs.Records {
-			recs = append(recs, r.Text)
+			recs = append(recs, normalizeTXT(r.Text))
 		}
 
 		d.Set("record_texts", recs)
@@ -177,6 +450,100 @@ func resourceVinylDNSRecordSetRead(d *schema.ResourceData, meta interface{}) err
 		return nil
 	}
 
+	if recordType == "mx" {
+		recs := make([]interface{}, 0, len(rs.Records))
+
+		for _, r := range rs.Records {
+			recs = append(recs, map[string]interface{}{
+				"preference": intValue(r.Preference),
+				"exchange":   r.Exchange,
+			})
+		}
+
+		if err := d.Set("record_mx", recs); err != nil {
+			return diag.Errorf("error setting record_mx for record set %s: %s", d.Id(), err)
+		}
+
+		return nil
+	}
+
+	if recordType == "srv" {
+		recs := make([]interface{}, 0, len(rs.Records))
+
+		for _, r := range rs.Records {
+			recs = append(recs, map[string]interface{}{
+				"priority": intValue(r.Priority),
+				"weight":   intValue(r.Weight),
+				"port":     intValue(r.Port),
+				"target":   r.Target,
+			})
+		}
+
+		if err := d.Set("record_srv", recs); err != nil {
+			return diag.Errorf("error setting record_srv for record set %s: %s", d.Id(), err)
+		}
+
+		return nil
+	}
+
+	if recordType == "naptr" {
+		recs := make([]interface{}, 0, len(rs.Records))
+
+		for _, r := range rs.Records {
+			recs = append(recs, map[string]interface{}{
+				"order":       intValue(r.Order),
+				"preference":  intValue(r.Preference),
+				"flags":       stringValue(r.Flags),
+				"service":     stringValue(r.Service),
+				"regexp":      stringValue(r.Regexp),
+				"replacement": r.Replacement,
+			})
+		}
+
+		if err := d.Set("record_naptr", recs); err != nil {
+			return diag.Errorf("error setting record_naptr for record set %s: %s", d.Id(), err)
+		}
+
+		return nil
+	}
+
+	if recordType == "ds" {
+		recs := make([]interface{}, 0, len(rs.Records))
+
+		for _, r := range rs.Records {
+			recs = append(recs, map[string]interface{}{
+				"key_tag":     intValue(r.KeyTag),
+				"algorithm":   r.Algorithm,
+				"digest_type": r.DigestType,
+				"digest":      strings.ToLower(r.Digest),
+			})
+		}
+
+		if err := d.Set("record_ds", recs); err != nil {
+			return diag.Errorf("error setting record_ds for record set %s: %s", d.Id(), err)
+		}
+
+		return nil
+	}
+
+	if recordType == "sshfp" {
+		recs := make([]interface{}, 0, len(rs.Records))
+
+		for _, r := range rs.Records {
+			recs = append(recs, map[string]interface{}{
+				"algorithm":   r.Algorithm,
+				"type":        r.Type,
+				"fingerprint": strings.ToLower(r.Fingerprint),
+			})
+		}
+
+		if err := d.Set("record_sshfp", recs); err != nil {
+			return diag.Errorf("error setting record_sshfp for record set %s: %s", d.Id(), err)
+		}
+
+		return nil
+	}
+
 	if recordType == "ns" {
 		recs := make([]interface{}, 0, len(rs.Records))
 
@@ -185,7 +552,7 @@ func resourceVinylDNSRecordSetRead(d *schema.ResourceData, meta interface{}) err
 		}
 
 		if err := d.Set("record_nsdnames", schema.NewSet(schema.HashString, recs)); err != nil {
-			return fmt.Errorf("error setting record_nsdnames for record set %s: %s", d.Id(), err)
+			return diag.Errorf("error setting record_nsdnames for record set %s: %s", d.Id(), err)
 		}
 
 		return nil
@@ -197,51 +564,79 @@ func resourceVinylDNSRecordSetRead(d *schema.ResourceData, meta interface{}) err
 	}
 
 	if err := d.Set("record_addresses", schema.NewSet(schema.HashString, recs)); err != nil {
-		return fmt.Errorf("error setting record_addresses for record set %s: %s", d.Id(), err)
+		return diag.Errorf("error setting record_addresses for record set %s: %s", d.Id(), err)
 	}
 
 	return nil
 }
 
-func resourceVinylDNSRecordSetUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceVinylDNSRecordSetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	zID, rsID, err := parseTwoPartID(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	log.Printf("[INFO] Updating vinyldns record set %s in zone %s", rsID, zID)
-	records, err := records(d)
-	if err != nil {
-		return err
+	if diags := recordSetLookupIDs(d, meta); diags.HasError() {
+		return diags
+	}
+
+	records, diags := records(d)
+	if diags.HasError() {
+		return diags
+	}
+	zoneID := d.Get("zone_id").(string)
+	deadline := time.Now().Add(d.Timeout(schema.TimeoutUpdate))
+	if err := meta.(*providerMeta).zoneLocks.Lock(ctx, zoneID, deadline); err != nil {
+		return errorDiagnostics(fmt.Sprintf("waiting for other changes in zone %s before updating recordset (%s)", zoneID, rsID), err)
 	}
-	updated, err := meta.(*vinyldns.Client).RecordSetUpdate(&vinyldns.RecordSet{
-		Name:         d.Get("name").(string),
-		ID:           rsID,
-		ZoneID:       d.Get("zone_id").(string),
-		OwnerGroupID: d.Get("owner_group_id").(string),
-		Type:         d.Get("type").(string),
-		TTL:          d.Get("ttl").(int),
-		Records:      records,
+	defer meta.(*providerMeta).zoneLocks.Unlock(zoneID)
+
+	var updated *recordSetUpdateResponse
+	err = retryOnPendingChange(ctx, meta, deadline, func() error {
+		var err error
+		updated, err = updateRecordSet(ctx, meta.(*providerMeta).client, &recordSet{
+			Name:         d.Get("name").(string),
+			ID:           rsID,
+			ZoneID:       zoneID,
+			OwnerGroupID: d.Get("owner_group_id").(string),
+			Type:         d.Get("type").(string),
+			TTL:          d.Get("ttl").(int),
+			Records:      records,
+		})
+		return err
 	})
 	if err != nil {
-		return err
+		return errorDiagnostics(fmt.Sprintf("updating recordset (%s)", rsID), err)
 	}
 
-	err = waitUntilRecordSetDeployed(d, meta, updated.ChangeID)
+	err = waitUntilRecordSetDeployed(ctx, d, meta, updated.ChangeID, time.Until(deadline))
 	if err != nil {
-		return err
+		return errorDiagnostics(fmt.Sprintf("waiting for recordset (%s) to be updated", rsID), err)
 	}
 
-	return resourceVinylDNSRecordSetRead(d, meta)
+	return resourceVinylDNSRecordSetRead(ctx, d, meta)
 }
 
-func resourceVinylDNSRecordSetDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceVinylDNSRecordSetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	zID, rsID, err := parseTwoPartID(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	log.Printf("[INFO] Deleting vinyldns record set %s in zone %s", rsID, zID)
 
-	deleted, err := meta.(*vinyldns.Client).RecordSetDelete(d.Get("zone_id").(string), rsID)
+	zoneID := d.Get("zone_id").(string)
+	deadline := time.Now().Add(d.Timeout(schema.TimeoutDelete))
+	if err := meta.(*providerMeta).zoneLocks.Lock(ctx, zoneID, deadline); err != nil {
+		return errorDiagnostics(fmt.Sprintf("waiting for other changes in zone %s before deleting recordset (%s)", zoneID, d.Id()), err)
+	}
+	defer meta.(*providerMeta).zoneLocks.Unlock(zoneID)
+
+	var deleted *recordSetUpdateResponse
+	err = retryOnPendingChange(ctx, meta, deadline, func() error {
+		var err error
+		deleted, err = deleteRecordSet(ctx, meta.(*providerMeta).client, zoneID, rsID)
+		return err
+	})
 	if err != nil {
 		if vErr, ok := err.(*vinyldns.Error); ok {
 			if vErr.ResponseCode == http.StatusNotFound {
@@ -250,40 +645,424 @@ func resourceVinylDNSRecordSetDelete(d *schema.ResourceData, meta interface{}) e
 				return nil
 			}
 
-			return fmt.Errorf("error deleting recordset (%s): %s", d.Id(), err)
+			return errorDiagnostics(fmt.Sprintf("deleting recordset (%s)", d.Id()), err)
 		}
 
-		return fmt.Errorf("error deleting recordset (%s): %s", d.Id(), err)
+		return errorDiagnostics(fmt.Sprintf("deleting recordset (%s)", d.Id()), err)
 	}
 
-	err = waitUntilRecordSetDeployed(d, meta, deleted.ChangeID)
+	err = waitUntilRecordSetDeployed(ctx, d, meta, deleted.ChangeID, time.Until(deadline))
 	if err != nil {
+		return errorDiagnostics(fmt.Sprintf("waiting for recordset (%s) to be deleted", d.Id()), err)
+	}
+
+	return nil
+}
+
+// resourceVinylDNSRecordSetCustomizeDiff checks at plan time that the
+// records match the record set type, which VinylDNS would otherwise only
+// reject at apply time. Values that are not yet known are skipped.
+func resourceVinylDNSRecordSetCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if err := recordSetNames(d, meta); err != nil {
+		return err
+	}
+
+	if err := recordSetNameInZone(d, meta); err != nil {
+		return err
+	}
+
+	if err := recordSetDefaults(d, meta); err != nil {
 		return err
 	}
 
+	if err := recordSetComputedChanges(d); err != nil {
+		return err
+	}
+
+	if !d.NewValueKnown("type") {
+		return nil
+	}
+
+	recordType := strings.ToLower(d.Get("type").(string))
+
+	if err := unsupportedRecordType(recordType); err != nil {
+		return fmt.Errorf("type: %s", err)
+	}
+
+	attr, ok := recordAttributes[recordType]
+	if !ok {
+		return fmt.Errorf("type: %q is not a record type supported by this provider", d.Get("type").(string))
+	}
+
+	var errs []error
+
+	if !recordAttributeSet(d, attr) {
+		errs = append(errs, fmt.Errorf("%s: must be set for %s records", attr, strings.ToUpper(recordType)))
+	}
+
+	for _, other := range recordAttributeNames() {
+		if other != attr && recordAttributeSet(d, other) {
+			errs = append(errs, fmt.Errorf("%s: cannot be set for %s records, which use %s", other, strings.ToUpper(recordType), attr))
+		}
+	}
+
+	if d.NewValueKnown(attr) {
+		switch recordType {
+		case "a", "aaaa":
+			for _, address := range stringSetToStringSlice(d.Get(attr).(*schema.Set)) {
+				if err := validateAddress(recordType, address); err != nil {
+					errs = append(errs, fmt.Errorf("%s: %s", attr, err))
+				}
+			}
+		case "cname":
+			cname := d.Get(attr).(string)
+			if strings.ContainsAny(cname, " \t\n,") {
+				errs = append(errs, fmt.Errorf("%s: a CNAME record set holds a single domain name, got %q", attr, cname))
+			} else if cname != "" && !strings.HasSuffix(cname, ".") {
+				errs = append(errs, fmt.Errorf("%s: must end in trailing '.', got %q", attr, cname))
+			}
+		case "ds":
+			for _, ds := range d.Get(attr).(*schema.Set).List() {
+				r := ds.(map[string]interface{})
+				if err := validateDSDigest(r["digest_type"].(int), r["digest"].(string)); err != nil {
+					errs = append(errs, fmt.Errorf("%s: %s", attr, err))
+				}
+			}
+		case "sshfp":
+			for _, sshfp := range d.Get(attr).(*schema.Set).List() {
+				r := sshfp.(map[string]interface{})
+				if err := validateSSHFPFingerprintType(r["type"].(int), r["fingerprint"].(string)); err != nil {
+					errs = append(errs, fmt.Errorf("%s: %s", attr, err))
+				}
+			}
+		case "ptr":
+			for _, ptrdName := range stringSetToStringSlice(d.Get(attr).(*schema.Set)) {
+				if !strings.HasSuffix(ptrdName, ".") {
+					errs = append(errs, fmt.Errorf("%s: %q must end in trailing '.'", attr, ptrdName))
+				}
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// recordSetNames resolves zone_name and owner_group_name, when they are
+// configured in place of zone_id and owner_group_id, to the IDs they name.
+func recordSetNames(d *schema.ResourceDiff, meta interface{}) error {
+	if _, ok := meta.(*providerMeta); !ok {
+		return nil
+	}
+
+	if configNull(d, "zone_id") && !configNull(d, "zone_name") {
+		if !d.NewValueKnown("zone_name") {
+			if d.Id() == "" {
+				return d.SetNewComputed("zone_id")
+			}
+		} else {
+			zone, err := zoneByName(meta, d.Get("zone_name").(string), lookupError)
+			if err != nil {
+				return fmt.Errorf("zone_name: %s", err)
+			}
+
+			if err := d.SetNew("zone_id", zone.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	if configNull(d, "owner_group_id") && !configNull(d, "owner_group_name") {
+		if !d.NewValueKnown("owner_group_name") {
+			return d.SetNewComputed("owner_group_id")
+		}
+
+		group, err := groupByName(meta, d.Get("owner_group_name").(string))
+		if err != nil {
+			return fmt.Errorf("owner_group_name: %s", err)
+		}
+
+		return d.SetNew("owner_group_id", group.ID)
+	}
+
+	return nil
+}
+
+// recordSetNameInZone checks that name, if written as an absolute name,
+// falls within the record set's zone, looking up the zone's name if
+// zone_id is configured instead of zone_name.
+func recordSetNameInZone(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("name") || !d.NewValueKnown("zone_name") {
+		return nil
+	}
+
+	// only "@" and absolute names depend on the zone's name
+	name := d.Get("name").(string)
+	if name != "@" && !strings.HasSuffix(name, ".") {
+		return nil
+	}
+
+	zoneName := d.Get("zone_name").(string)
+
+	if m, ok := meta.(*providerMeta); ok && zoneName == "" && d.NewValueKnown("zone_id") && d.Get("zone_id").(string) != "" {
+		zoneID := d.Get("zone_id").(string)
+
+		zone, err := m.client.Zone(zoneID)
+		if err != nil {
+			return fmt.Errorf("zone_id: %s", lookupError(fmt.Sprintf("zone %s", zoneID), err))
+		}
+
+		zoneName = zone.Name
+		if err := d.SetNew("zone_name", zoneName); err != nil {
+			return err
+		}
+	}
+
+	if zoneName == "" {
+		return nil
+	}
+
+	if _, err := canonicalRecordName(name, zoneName); err != nil {
+		return fmt.Errorf("name: %s", err)
+	}
+
+	return nil
+}
+
+// canonicalRecordName returns name in the form VinylDNS uses for a record
+// in zoneName: the zone name with a trailing dot for the zone apex, which
+// may also be written "@", and otherwise the name relative to the zone. An
+// absolute name, one ending in a dot, must fall within the zone. Unicode
+// labels in either name are converted to punycode.
+func canonicalRecordName(name, zoneName string) (string, error) {
+	zone, err := asciiName(strings.TrimSuffix(zoneName, "."))
+	if err != nil {
+		return "", err
+	}
+
+	if name == "@" {
+		return zone + ".", nil
+	}
+
+	ascii, err := asciiName(name)
+	if err != nil {
+		return "", err
+	}
+
+	if strings.EqualFold(strings.TrimSuffix(ascii, "."), zone) {
+		return zone + ".", nil
+	}
+
+	if !strings.HasSuffix(ascii, ".") {
+		return ascii, nil
+	}
+
+	fqdn := strings.TrimSuffix(ascii, ".")
+	suffix := "." + zone
+	if len(fqdn) > len(suffix) && strings.EqualFold(fqdn[len(fqdn)-len(suffix):], suffix) {
+		return fqdn[:len(fqdn)-len(suffix)], nil
+	}
+
+	return "", fmt.Errorf("%q is not within zone %q", name, strings.TrimSuffix(zoneName, ".")+".")
+}
+
+// recordSetName returns the record set's name in canonical form, or in
+// punycode if the zone's name is not known.
+func recordSetName(d *schema.ResourceData) string {
+	name := d.Get("name").(string)
+
+	if zoneName := d.Get("zone_name").(string); zoneName != "" {
+		if canonical, err := canonicalRecordName(name, zoneName); err == nil {
+			return canonical
+		}
+	}
+
+	if ascii, err := asciiName(name); err == nil {
+		return ascii
+	}
+
+	return name
+}
+
+// suppressRecordNameDiff treats names that mean the same record as equal:
+// names that differ only in case, "@" and the zone name for the apex, and
+// absolute and relative names within the zone, and Unicode names and their
+// punycode form.
+func suppressRecordNameDiff(k, old, new string, d *schema.ResourceData) bool {
+	zoneName := d.Get("zone_name").(string)
+	if zoneName == "" {
+		oldName, oldErr := asciiName(old)
+		newName, newErr := asciiName(new)
+		return oldErr == nil && newErr == nil && strings.EqualFold(oldName, newName)
+	}
+
+	oldName, err := canonicalRecordName(old, zoneName)
+	if err != nil {
+		return false
+	}
+
+	newName, err := canonicalRecordName(new, zoneName)
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(oldName, newName)
+}
+
+// recordSetDefaults plans the provider's default_ttl and
+// default_owner_group_id for a record set that leaves ttl or owner_group_id
+// out of its configuration. Without a default, the attribute keeps the
+// value VinylDNS chose.
+func recordSetDefaults(d *schema.ResourceDiff, meta interface{}) error {
+	m, ok := meta.(*providerMeta)
+	if !ok {
+		return nil
+	}
+
+	if m.defaultTTL != 0 && configNull(d, "ttl") {
+		if err := d.SetNew("ttl", m.defaultTTL); err != nil {
+			return err
+		}
+	}
+
+	if m.defaultOwnerGroupID != "" && configNull(d, "owner_group_id") && configNull(d, "owner_group_name") {
+		if err := d.SetNew("owner_group_id", m.defaultOwnerGroupID); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-func records(d *schema.ResourceData) ([]vinyldns.Record, error) {
+// configNull reports whether the top level attribute key is left out of
+// the configuration.
+func configNull(d *schema.ResourceDiff, key string) bool {
+	raw := d.GetRawConfig()
+	if raw.IsNull() || !raw.IsKnown() {
+		return false
+	}
+
+	return raw.GetAttr(key).IsNull()
+}
+
+// recordSetComputedChanges marks the computed attributes that an update
+// will change as unknown, so the plan does not promise their old values.
+func recordSetComputedChanges(d *schema.ResourceDiff) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	if recordSetHasChanges(d, append([]string{"ttl", "owner_group_id"}, recordAttributeNames()...)...) {
+		for _, k := range []string{"status", "updated"} {
+			if err := d.SetNewComputed(k); err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.HasChange("owner_group_id") && configNull(d, "owner_group_name") {
+		return d.SetNewComputed("owner_group_name")
+	}
+
+	return nil
+}
+
+// recordSetHasChanges reports whether any of keys changes, comparing sets
+// by their hash functions, so records read back from VinylDNS in a
+// different but equivalent form do not count as a change.
+func recordSetHasChanges(d *schema.ResourceDiff, keys ...string) bool {
+	for _, k := range keys {
+		if !d.HasChange(k) {
+			continue
+		}
+
+		o, n := d.GetChange(k)
+		if old, ok := o.(*schema.Set); ok && d.NewValueKnown(k) {
+			new := n.(*schema.Set)
+			if old.Difference(new).Len() == 0 && new.Difference(old).Len() == 0 {
+				continue
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// recordAttributeSet reports whether attr holds any records, counting a
+// value that is not yet known as set.
+func recordAttributeSet(d *schema.ResourceDiff, attr string) bool {
+	if !d.NewValueKnown(attr) {
+		return true
+	}
+
+	switch v := d.Get(attr).(type) {
+	case string:
+		return v != ""
+	case *schema.Set:
+		return v.Len() > 0
+	}
+
+	return false
+}
+
+func recordAttributeNames() []string {
+	seen := map[string]bool{}
+	names := []string{}
+
+	for _, attr := range recordAttributes {
+		if !seen[attr] {
+			seen[attr] = true
+			names = append(names, attr)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// validateAddress checks that an address is an IPv4 address for A records
+// or an IPv6 address, optionally in brackets, for AAAA records.
+func validateAddress(recordType, address string) error {
+	ip := net.ParseIP(removeBrackets(address))
+
+	if recordType == "a" && (ip == nil || ip.To4() == nil || strings.Contains(address, ":")) {
+		return fmt.Errorf("%q is not an IPv4 address", address)
+	}
+
+	if recordType == "aaaa" && (ip == nil || !strings.Contains(address, ":")) {
+		return fmt.Errorf("%q is not an IPv6 address", address)
+	}
+
+	return nil
+}
+
+func records(d *schema.ResourceData) ([]record, diag.Diagnostics) {
 	recordType := strings.ToLower(d.Get("type").(string))
 
 	// SOA records are currently read-only and cannot be created, updated or deleted by vinyldns
-	if recordType == "soa" {
-		return []vinyldns.Record{}, errors.New(recordType + " records are not currently supported by vinyldns")
+	if err := unsupportedRecordType(recordType); err != nil {
+		return []record{}, attributeError(cty.GetAttrPath("type"), "%s", err)
 	}
 
 	if recordType == "ptr" {
-		return ptrRecordSets(stringSetToStringSlice(d.Get("record_ptrdnames").(*schema.Set)))
+		records, err := ptrRecordSets(stringSetToStringSlice(d.Get("record_ptrdnames").(*schema.Set)))
+		if err != nil {
+			return records, attributeError(cty.GetAttrPath("record_ptrdnames"), "%s", err)
+		}
+
+		return records, nil
 	}
 
 	if recordType == "cname" {
 		cname := d.Get("record_cname").(string)
 
-		if string(cname[len(cname)-1:]) != "." {
-			return []vinyldns.Record{}, errors.New("record_cname must end in trailing '.'")
+		if !strings.HasSuffix(cname, ".") {
+			return []record{}, attributeError(cty.GetAttrPath("record_cname"), "record_cname must end in trailing '.'")
 		}
 
-		return []vinyldns.Record{
+		return []record{
 			{
 				CName: cname,
 			},
@@ -294,6 +1073,26 @@ func records(d *schema.ResourceData) ([]vinyldns.Record, error) {
 		return txtRecordSets(stringSetToStringSlice(d.Get("record_texts").(*schema.Set))), nil
 	}
 
+	if recordType == "mx" {
+		return mxRecordSets(d.Get("record_mx").(*schema.Set).List()), nil
+	}
+
+	if recordType == "srv" {
+		return srvRecordSets(d.Get("record_srv").(*schema.Set).List()), nil
+	}
+
+	if recordType == "naptr" {
+		return naptrRecordSets(d.Get("record_naptr").(*schema.Set).List()), nil
+	}
+
+	if recordType == "ds" {
+		return dsRecordSets(d.Get("record_ds").(*schema.Set).List()), nil
+	}
+
+	if recordType == "sshfp" {
+		return sshfpRecordSets(d.Get("record_sshfp").(*schema.Set).List()), nil
+	}
+
 	if recordType == "ns" {
 		return nsRecordSets(stringSetToStringSlice(d.Get("record_nsdnames").(*schema.Set))), nil
 	}
@@ -301,31 +1100,31 @@ func records(d *schema.ResourceData) ([]vinyldns.Record, error) {
 	return addressRecordSets(stringSetToStringSlice(d.Get("record_addresses").(*schema.Set))), nil
 }
 
-func addressRecordSets(addresses []string) []vinyldns.Record {
-	records := []vinyldns.Record{}
+func addressRecordSets(addresses []string) []record {
+	records := []record{}
 	recordsCount := len(addresses)
 
 	for i := 0; i < recordsCount; i++ {
-		records = append(records, vinyldns.Record{
-			Address: removeBrackets(addresses[i]),
+		records = append(records, record{
+			Address: canonicalAddress(addresses[i]),
 		})
 	}
 
 	return records
 }
 
-func ptrRecordSets(ptrdNames []string) ([]vinyldns.Record, error) {
-	records := []vinyldns.Record{}
+func ptrRecordSets(ptrdNames []string) ([]record, error) {
+	records := []record{}
 	recordsCount := len(ptrdNames)
 
 	for i := 0; i < recordsCount; i++ {
 		ptrdName := ptrdNames[i]
 
-		if string(ptrdName[len(ptrdName)-1:]) != "." {
-			return []vinyldns.Record{}, errors.New("record_ptrdnames value must end in trailing '.'")
+		if !strings.HasSuffix(ptrdName, ".") {
+			return []record{}, errors.New("record_ptrdnames value must end in trailing '.'")
 		}
 
-		records = append(records, vinyldns.Record{
+		records = append(records, record{
 			PTRDName: ptrdNames[i],
 		})
 	}
@@ -333,25 +1132,146 @@ func ptrRecordSets(ptrdNames []string) ([]vinyldns.Record, error) {
 	return records, nil
 }
 
-func txtRecordSets(texts []string) []vinyldns.Record {
-	records := []vinyldns.Record{}
+func txtRecordSets(texts []string) []record {
+	records := []record{}
 	recordsCount := len(texts)
 
 	for i := 0; i < recordsCount; i++ {
-		records = append(records, vinyldns.Record{
-			Text: texts[i],
+		records = append(records, record{
+			Text: chunkTXT(normalizeTXT(texts[i])),
 		})
 	}
 
 	return records
 }
 
-func nsRecordSets(nsdnames []string) []vinyldns.Record {
-	records := []vinyldns.Record{}
+// txtMaxStringLength is the length in bytes of the longest character-string
+// a TXT record can hold. Longer values are split across several.
+const txtMaxStringLength = 255
+
+// normalizeTXT returns the value of a TXT record written either as plain
+// text or as a sequence of quoted character-strings, such as
+// "v=DKIM1; k=rsa; " "p=MIGf...", in which case the strings are unquoted,
+// unescaped and joined. Both forms of the same value normalize alike.
+func normalizeTXT(text string) string {
+	if strs, ok := parseTXTStrings(text); ok {
+		return strings.Join(strs, "")
+	}
+
+	return text
+}
+
+// chunkTXT returns text unchanged if it fits in one character-string, and
+// otherwise as a sequence of quoted character-strings of at most
+// txtMaxStringLength bytes each. Chunks end on a rune boundary, so a
+// multi-byte character is never split across two character-strings.
+func chunkTXT(text string) string {
+	if len(text) <= txtMaxStringLength {
+		return text
+	}
+
+	var chunks []string
+	for len(text) > 0 {
+		n := txtMaxStringLength
+		if len(text) < n {
+			n = len(text)
+		}
+		for n < len(text) && n > 0 && !utf8.RuneStart(text[n]) {
+			n--
+		}
+		if n == 0 {
+			n = txtMaxStringLength
+		}
+
+		chunks = append(chunks, quoteTXT(text[:n]))
+		text = text[n:]
+	}
+
+	return strings.Join(chunks, " ")
+}
+
+func quoteTXT(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// parseTXTStrings parses text as a whitespace separated sequence of quoted
+// character-strings, reporting false if it is not one. Escapes are a
+// backslash followed by either one character or three decimal digits.
+func parseTXTStrings(text string) ([]string, bool) {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, `"`) {
+		return nil, false
+	}
+
+	var strs []string
+	for len(text) > 0 {
+		if text[0] != '"' {
+			return nil, false
+		}
+
+		var b strings.Builder
+		i := 1
+		for ; i < len(text) && text[i] != '"'; i++ {
+			if text[i] != '\\' {
+				b.WriteByte(text[i])
+				continue
+			}
+
+			if i+3 < len(text) && isDigits(text[i+1:i+4]) {
+				n, _ := strconv.Atoi(text[i+1 : i+4])
+				if n > 255 {
+					return nil, false
+				}
+				b.WriteByte(byte(n))
+				i += 3
+				continue
+			}
+
+			if i+1 >= len(text) {
+				return nil, false
+			}
+			i++
+			b.WriteByte(text[i])
+		}
+
+		if i >= len(text) {
+			return nil, false
+		}
+
+		strs = append(strs, b.String())
+
+		rest := text[i+1:]
+		text = strings.TrimLeft(rest, " \t")
+		if len(text) > 0 && len(text) == len(rest) {
+			return nil, false
+		}
+	}
+
+	return strs, true
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// txtHash hashes a TXT value in its normalized form, so a value written as
+// quoted character-strings matches the same value read back unquoted.
+func txtHash(v interface{}) int {
+	return schema.HashString(normalizeTXT(v.(string)))
+}
+
+func nsRecordSets(nsdnames []string) []record {
+	records := []record{}
 	recordsCount := len(nsdnames)
 
 	for i := 0; i < recordsCount; i++ {
-		records = append(records, vinyldns.Record{
+		records = append(records, record{
 			NSDName: nsdnames[i],
 		})
 	}
@@ -359,29 +1279,198 @@ func nsRecordSets(nsdnames []string) []vinyldns.Record {
 	return records
 }
 
-func waitUntilRecordSetDeployed(d *schema.ResourceData, meta interface{}, changeID string) error {
-	stateConf := &resource.StateChangeConf{
-		Pending:      []string{"Pending", ""},
-		Target:       []string{"Complete"},
-		Refresh:      recordSetStateRefreshFunc(d, meta, changeID),
-		Timeout:      30 * time.Minute,
-		Delay:        500 * time.Millisecond,
-		MinTimeout:   15 * time.Second,
-		PollInterval: 500 * time.Millisecond,
+func mxRecordSets(mxs []interface{}) []record {
+	records := []record{}
+
+	for _, mx := range mxs {
+		m := mx.(map[string]interface{})
+
+		records = append(records, record{
+			Preference: intPtr(m["preference"].(int)),
+			Exchange:   m["exchange"].(string),
+		})
+	}
+
+	return records
+}
+
+// unsupportedRecordType returns an error for record types the provider
+// cannot manage: SOA records, which are owned by VinylDNS itself.
+func unsupportedRecordType(recordType string) error {
+	if recordType == "soa" {
+		return fmt.Errorf("%s records are not currently supported by vinyldns", recordType)
+	}
+
+	return nil
+}
+
+func srvRecordSets(srvs []interface{}) []record {
+	records := []record{}
+
+	for _, srv := range srvs {
+		s := srv.(map[string]interface{})
+
+		records = append(records, record{
+			Priority: intPtr(s["priority"].(int)),
+			Weight:   intPtr(s["weight"].(int)),
+			Port:     intPtr(s["port"].(int)),
+			Target:   s["target"].(string),
+		})
+	}
+
+	return records
+}
+
+func naptrRecordSets(naptrs []interface{}) []record {
+	records := []record{}
+
+	for _, naptr := range naptrs {
+		n := naptr.(map[string]interface{})
+
+		records = append(records, record{
+			Order:       intPtr(n["order"].(int)),
+			Preference:  intPtr(n["preference"].(int)),
+			Flags:       stringPtr(n["flags"].(string)),
+			Service:     stringPtr(n["service"].(string)),
+			Regexp:      stringPtr(n["regexp"].(string)),
+			Replacement: n["replacement"].(string),
+		})
+	}
+
+	return records
+}
+
+func dsRecordSets(dss []interface{}) []record {
+	records := []record{}
+
+	for _, ds := range dss {
+		r := ds.(map[string]interface{})
+
+		records = append(records, record{
+			KeyTag:     intPtr(r["key_tag"].(int)),
+			Algorithm:  r["algorithm"].(int),
+			DigestType: r["digest_type"].(int),
+			Digest:     strings.ToLower(r["digest"].(string)),
+		})
+	}
+
+	return records
+}
+
+// dsRecordHash hashes a record_ds block ignoring the case of the digest.
+func dsRecordHash(v interface{}) int {
+	r := v.(map[string]interface{})
+
+	return schema.HashString(fmt.Sprintf("%d-%d-%d-%s", r["key_tag"].(int), r["algorithm"].(int), r["digest_type"].(int), strings.ToLower(r["digest"].(string))))
+}
+
+// validateDSDigest validates that a DS digest has the length of its
+// digest type.
+func validateDSDigest(digestType int, digest string) error {
+	if length, ok := dsDigestLengths[digestType]; ok && len(digest) != length {
+		return fmt.Errorf("digest type %d needs a digest of %d hex digits, got %d", digestType, length, len(digest))
+	}
+
+	return nil
+}
+
+// validateSSHFPFingerprintType validates that an SSHFP fingerprint has the
+// length of its fingerprint type.
+func validateSSHFPFingerprintType(fpType int, fingerprint string) error {
+	if length, ok := sshfpFingerprintLengths[fpType]; ok && len(fingerprint) != length {
+		return fmt.Errorf("fingerprint type %d needs a fingerprint of %d hex digits, got %d", fpType, length, len(fingerprint))
+	}
+
+	return nil
+}
+
+func sshfpRecordSets(sshfps []interface{}) []record {
+	records := []record{}
+
+	for _, sshfp := range sshfps {
+		s := sshfp.(map[string]interface{})
+
+		records = append(records, record{
+			Algorithm:   s["algorithm"].(int),
+			Type:        s["type"].(int),
+			Fingerprint: strings.ToLower(s["fingerprint"].(string)),
+		})
 	}
 
-	_, err := stateConf.WaitForState()
+	return records
+}
+
+// sshfpRecordHash hashes a record_sshfp block ignoring the case of the
+// fingerprint, so a fingerprint written in upper case in the configuration
+// matches the lower case one read back from VinylDNS.
+func sshfpRecordHash(v interface{}) int {
+	s := v.(map[string]interface{})
+
+	return schema.HashString(fmt.Sprintf("%d-%d-%s", s["algorithm"].(int), s["type"].(int), strings.ToLower(s["fingerprint"].(string))))
+}
+
+// addressHash hashes an address in its canonical form, so an IPv6 address
+// written in the configuration in any of its equivalent forms matches the
+// form read back from VinylDNS.
+func addressHash(v interface{}) int {
+	return schema.HashString(canonicalAddress(v.(string)))
+}
+
+func waitUntilRecordSetDeployed(ctx context.Context, d *schema.ResourceData, meta interface{}, changeID string, timeout time.Duration) error {
+	waiter := &changeWaiter{
+		Pending: []string{"Pending", ""},
+		Target:  []string{"Complete"},
+		Refresh: recordSetStateRefreshFunc(ctx, d, meta, changeID),
+		Timeout: timeout,
+		Polling: meta.(*providerMeta).polling,
+	}
+
+	_, err := waiter.WaitForStateContext(ctx)
 	return err
 }
 
-func recordSetStateRefreshFunc(d *schema.ResourceData, meta interface{}, changeID string) resource.StateRefreshFunc {
+// retryOnPendingChange calls f until it succeeds, fails with an error other
+// than a pending change conflict, the deadline passes or ctx is cancelled.
+// VinylDNS responds 409 to a record set change while another change in the
+// zone is pending.
+func retryOnPendingChange(ctx context.Context, meta interface{}, deadline time.Time, f func() error) error {
+	polling := meta.(*providerMeta).polling
+
+	for attempt := 0; ; attempt++ {
+		err := f()
+		if err == nil || !isPendingChangeConflict(err) {
+			return err
+		}
+
+		wait := polling.interval(attempt)
+		if time.Now().Add(wait).After(deadline) {
+			return fmt.Errorf("timed out waiting for a pending change to complete: %s", err)
+		}
+
+		log.Printf("[INFO] another change is pending; retrying in %s", wait)
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+func isPendingChangeConflict(err error) bool {
+	vErr, ok := err.(*vinyldns.Error)
+	if !ok || vErr.ResponseCode != http.StatusConflict {
+		return false
+	}
+
+	return strings.Contains(strings.ToLower(vErr.ResponseBody), "pending")
+}
+
+func recordSetStateRefreshFunc(ctx context.Context, d *schema.ResourceData, meta interface{}, changeID string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		_, rsID, err := parseTwoPartID(d.Id())
 		if err != nil {
 			return nil, "", err
 		}
 		log.Printf("[INFO] waiting for %v Complete status", d.Id())
-		rsc, err := meta.(*vinyldns.Client).RecordSetChange(d.Get("zone_id").(string), rsID, changeID)
+		rsc, err := getRecordSetChange(ctx, meta.(*providerMeta).client, d.Get("zone_id").(string), rsID, changeID)
 		if err != nil {
 			if dErr, ok := err.(*vinyldns.Error); ok {
 				if dErr.ResponseCode == http.StatusNotFound {
@@ -397,8 +1486,8 @@ func recordSetStateRefreshFunc(d *schema.ResourceData, meta interface{}, changeI
 		}
 
 		if rsc.Status == "Failed" {
-			err = errors.New("record set status Failed")
-			log.Printf("[ERROR] record set status Failed: %#v", err)
+			err = recordSetChangeFailedError(rsc)
+			log.Printf("[ERROR] %s", err)
 			return rsc, rsc.Status, err
 		}
 
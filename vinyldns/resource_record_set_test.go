@@ -13,11 +13,21 @@ limitations under the License.
 package vinyldns
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
 	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/vinyldns/go-vinyldns/vinyldns"
 )
@@ -36,6 +46,11 @@ func TestAccVinylDNSRecordSetBasic(t *testing.T) {
 					testAccCheckVinylDNSRecordSetExists("vinyldns_record_set.test_txt_record_set"),
 					testAccCheckVinylDNSRecordSetExists("vinyldns_record_set.test_ns_record_set"),
 					testAccCheckVinylDNSRecordSetExists("vinyldns_record_set.test_ptr_record_set"),
+					testAccCheckVinylDNSRecordSetExists("vinyldns_record_set.test_mx_record_set"),
+					testAccCheckVinylDNSRecordSetExists("vinyldns_record_set.test_srv_record_set"),
+					testAccCheckVinylDNSRecordSetExists("vinyldns_record_set.test_sshfp_record_set"),
+					testAccCheckVinylDNSRecordSetExists("vinyldns_record_set.test_naptr_record_set"),
+					testAccCheckVinylDNSRecordSetExists("vinyldns_record_set.test_ds_record_set"),
 					resource.TestCheckResourceAttr("vinyldns_record_set.test_a_record_set", "name", "terraformtestrecordset"),
 					resource.TestCheckResourceAttr("vinyldns_record_set.test_a_record_set", "type", "A"),
 					resource.TestCheckResourceAttr("vinyldns_record_set.test_a_record_set", "ttl", "6000"),
@@ -59,6 +74,45 @@ func TestAccVinylDNSRecordSetBasic(t *testing.T) {
 					resource.TestCheckTypeSetElemAttr("vinyldns_record_set.test_ptr_record_set", "record_ptrdnames.*", "ptr.terraformtestrecordset."),
 					resource.TestCheckResourceAttr("vinyldns_record_set.test_ptr_record_set", "type", "PTR"),
 					resource.TestCheckResourceAttr("vinyldns_record_set.test_ptr_record_set", "ttl", "6000"),
+					resource.TestCheckResourceAttr("vinyldns_record_set.test_mx_record_set", "type", "MX"),
+					resource.TestCheckResourceAttr("vinyldns_record_set.test_mx_record_set", "record_mx.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("vinyldns_record_set.test_mx_record_set", "record_mx.*", map[string]string{
+						"preference": "10",
+						"exchange":   "mail1.system-test.",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("vinyldns_record_set.test_mx_record_set", "record_mx.*", map[string]string{
+						"preference": "20",
+						"exchange":   "mail2.system-test.",
+					}),
+					resource.TestCheckResourceAttr("vinyldns_record_set.test_srv_record_set", "type", "SRV"),
+					resource.TestCheckTypeSetElemNestedAttrs("vinyldns_record_set.test_srv_record_set", "record_srv.*", map[string]string{
+						"priority": "10",
+						"weight":   "60",
+						"port":     "5060",
+						"target":   "sip.system-test.",
+					}),
+					resource.TestCheckResourceAttr("vinyldns_record_set.test_sshfp_record_set", "type", "SSHFP"),
+					resource.TestCheckTypeSetElemNestedAttrs("vinyldns_record_set.test_sshfp_record_set", "record_sshfp.*", map[string]string{
+						"algorithm":   "4",
+						"type":        "2",
+						"fingerprint": "1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef",
+					}),
+					resource.TestCheckResourceAttr("vinyldns_record_set.test_naptr_record_set", "type", "NAPTR"),
+					resource.TestCheckTypeSetElemNestedAttrs("vinyldns_record_set.test_naptr_record_set", "record_naptr.*", map[string]string{
+						"order":       "100",
+						"preference":  "10",
+						"flags":       "U",
+						"service":     "E2U+sip",
+						"regexp":      "!^.*$!sip:info@system-test!",
+						"replacement": ".",
+					}),
+					resource.TestCheckResourceAttr("vinyldns_record_set.test_ds_record_set", "type", "DS"),
+					resource.TestCheckTypeSetElemNestedAttrs("vinyldns_record_set.test_ds_record_set", "record_ds.*", map[string]string{
+						"key_tag":     "60485",
+						"algorithm":   "5",
+						"digest_type": "1",
+						"digest":      "2bb183af5f22588179a53b0a98631fad1a292118",
+					}),
 				),
 			},
 			resource.TestStep{
@@ -91,6 +145,31 @@ func TestAccVinylDNSRecordSetBasic(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateCheck:  testAccVinylDNSRecordSetImportPTRRecordStateCheck,
 			},
+			resource.TestStep{
+				ResourceName:      "vinyldns_record_set.test_mx_record_set",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			resource.TestStep{
+				ResourceName:      "vinyldns_record_set.test_srv_record_set",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			resource.TestStep{
+				ResourceName:      "vinyldns_record_set.test_sshfp_record_set",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			resource.TestStep{
+				ResourceName:      "vinyldns_record_set.test_naptr_record_set",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			resource.TestStep{
+				ResourceName:      "vinyldns_record_set.test_ds_record_set",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -117,6 +196,965 @@ func TestAccVinylDNSRecordSetMoveZones(t *testing.T) {
 	})
 }
 
+// testAccLongTXT is longer than one 255 byte character-string, and has a
+// multi-byte character where a cut at a fixed byte offset would split it.
+var testAccLongTXT = "v=DKIM1; k=rsa; p=" + strings.Repeat("A", 236) + "é" + strings.Repeat("B", 100)
+
+// TestAccVinylDNSRecordSetLongTXT checks that a TXT value longer than 255
+// bytes is stored by VinylDNS as the quoted character-strings it is sent as,
+// and reads back as the value written, without a diff.
+func TestAccVinylDNSRecordSetLongTXT(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccVinylDNSRecordSetDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccVinylDNSRecordSetConfigLongTXT(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVinylDNSRecordSetExists("vinyldns_record_set.test_long_txt_record_set"),
+					resource.TestCheckTypeSetElemAttr("vinyldns_record_set.test_long_txt_record_set", "record_texts.*", testAccLongTXT),
+					testAccCheckVinylDNSRecordSetStoredText("vinyldns_record_set.test_long_txt_record_set", chunkTXT(testAccLongTXT)),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "vinyldns_record_set.test_long_txt_record_set",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestIsPendingChangeConflict(t *testing.T) {
+	cases := map[string]struct {
+		Err      error
+		Expected bool
+	}{
+		"pending change": {
+			Err: &vinyldns.Error{
+				ResponseCode: http.StatusConflict,
+				ResponseBody: `RecordSet with id rs-id and name www cannot be updated in zone ok. because a change is pending`,
+			},
+			Expected: true,
+		},
+		"already exists": {
+			Err: &vinyldns.Error{
+				ResponseCode: http.StatusConflict,
+				ResponseBody: `RecordSet with name www and type A already exists in zone ok.`,
+			},
+			Expected: false,
+		},
+		"not a conflict": {
+			Err: &vinyldns.Error{
+				ResponseCode: http.StatusBadRequest,
+				ResponseBody: `pending`,
+			},
+			Expected: false,
+		},
+		"not a vinyldns error": {
+			Err:      errors.New("pending"),
+			Expected: false,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			if got := isPendingChangeConflict(tc.Err); got != tc.Expected {
+				t.Fatalf("expected %t, got %t", tc.Expected, got)
+			}
+		})
+	}
+}
+
+func TestRetryOnPendingChange(t *testing.T) {
+	meta := &providerMeta{polling: testFastPolling()}
+	pending := &vinyldns.Error{ResponseCode: http.StatusConflict, ResponseBody: "a change is pending"}
+
+	calls := 0
+	err := retryOnPendingChange(context.Background(), meta, time.Now().Add(time.Second), func() error {
+		calls++
+		if calls < 3 {
+			return pending
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("did not expect an error but one was raised: %s", err)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", calls)
+	}
+
+	calls = 0
+	err = retryOnPendingChange(context.Background(), meta, time.Now().Add(10*time.Millisecond), func() error {
+		calls++
+		return pending
+	})
+	if err == nil {
+		t.Fatalf("expected a timeout error but one was not raised")
+	}
+
+	other := errors.New("boom")
+	calls = 0
+	err = retryOnPendingChange(context.Background(), meta, time.Now().Add(time.Second), func() error {
+		calls++
+		return other
+	})
+	if err != other || calls != 1 {
+		t.Fatalf("expected other errors to be returned without retrying; got %v after %d calls", err, calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = retryOnPendingChange(ctx, meta, time.Now().Add(time.Second), func() error {
+		return pending
+	})
+	if err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestResourceVinylDNSRecordSetReadRoundTrip(t *testing.T) {
+	cases := map[string]struct {
+		Type     string
+		Records  string
+		Expected []record
+	}{
+		"A": {
+			Type:     "A",
+			Records:  `[{"address":"192.0.2.1"}]`,
+			Expected: []record{{Address: "192.0.2.1"}},
+		},
+		"AAAA": {
+			Type:     "AAAA",
+			Records:  `[{"address":"2001:db8::1"}]`,
+			Expected: []record{{Address: "2001:db8::1"}},
+		},
+		"TXT": {
+			Type:     "TXT",
+			Records:  `[{"text":"v=spf1 -all"}]`,
+			Expected: []record{{Text: "v=spf1 -all"}},
+		},
+		"quoted TXT": {
+			Type:     "TXT",
+			Records:  `[{"text":"\"v=spf1 \" \"-all\""}]`,
+			Expected: []record{{Text: "v=spf1 -all"}},
+		},
+		"long TXT": {
+			Type:     "TXT",
+			Records:  fmt.Sprintf(`[{"text":"\"%s\" \"%s\""}]`, strings.Repeat("a", 255), strings.Repeat("b", 45)),
+			Expected: []record{{Text: fmt.Sprintf(`"%s" "%s"`, strings.Repeat("a", 255), strings.Repeat("b", 45))}},
+		},
+		"MX": {
+			Type:    "MX",
+			Records: `[{"preference":10,"exchange":"mail1.example.com."},{"preference":20,"exchange":"mail2.example.com."}]`,
+			Expected: []record{
+				{Preference: intPtr(10), Exchange: "mail1.example.com."},
+				{Preference: intPtr(20), Exchange: "mail2.example.com."},
+			},
+		},
+		"null MX": {
+			Type:     "MX",
+			Records:  `[{"preference":0,"exchange":"."}]`,
+			Expected: []record{{Preference: intPtr(0), Exchange: "."}},
+		},
+		"SRV": {
+			Type:     "SRV",
+			Records:  `[{"priority":10,"weight":60,"port":5060,"target":"sip.example.com."}]`,
+			Expected: []record{{Priority: intPtr(10), Weight: intPtr(60), Port: intPtr(5060), Target: "sip.example.com."}},
+		},
+		"SRV with priority and weight 0": {
+			Type:     "SRV",
+			Records:  `[{"priority":0,"weight":0,"port":5060,"target":"sip.example.com."}]`,
+			Expected: []record{{Priority: intPtr(0), Weight: intPtr(0), Port: intPtr(5060), Target: "sip.example.com."}},
+		},
+		"NAPTR": {
+			Type:    "NAPTR",
+			Records: `[{"order":0,"preference":10,"flags":"U","service":"E2U+sip","regexp":"!^.*$!sip:info@example.com!","replacement":"."}]`,
+			Expected: []record{
+				{Order: intPtr(0), Preference: intPtr(10), Flags: stringPtr("U"), Service: stringPtr("E2U+sip"), Regexp: stringPtr("!^.*$!sip:info@example.com!"), Replacement: "."},
+			},
+		},
+		"DS": {
+			Type:     "DS",
+			Records:  `[{"keytag":60485,"algorithm":5,"digesttype":1,"digest":"2BB183AF5F22588179A53B0A98631FAD1A292118"}]`,
+			Expected: []record{{KeyTag: intPtr(60485), Algorithm: 5, DigestType: 1, Digest: "2bb183af5f22588179a53b0a98631fad1a292118"}},
+		},
+		"SSHFP": {
+			Type:     "SSHFP",
+			Records:  `[{"algorithm":4,"type":2,"fingerprint":"1234567890ABCDEF1234567890ABCDEF1234567890ABCDEF1234567890ABCDEF"}]`,
+			Expected: []record{{Algorithm: 4, Type: 2, Fingerprint: "1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"}},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			meta := testAPIServer(t, "/zones/zone-id/recordsets/rs-id", fmt.Sprintf(`{"recordSet": {
+				"id": "rs-id",
+				"zoneId": "zone-id",
+				"name": "www",
+				"type": %q,
+				"ttl": 300,
+				"records": %s
+			}}`, tc.Type, tc.Records))
+
+			d := schema.TestResourceDataRaw(t, resourceVinylDNSRecordSet().Schema, map[string]interface{}{})
+			d.SetId("zone-id:rs-id")
+
+			if diags := resourceVinylDNSRecordSetRead(context.Background(), d, meta); diags.HasError() {
+				t.Fatalf("did not expect an error but one was raised: %#v", diags)
+			}
+
+			records, diags := records(d)
+			if diags.HasError() {
+				t.Fatalf("did not expect an error but one was raised: %#v", diags)
+			}
+
+			sort.Slice(records, func(i, j int) bool { return testRecordJSON(t, records[i]) < testRecordJSON(t, records[j]) })
+			if !reflect.DeepEqual(records, tc.Expected) {
+				t.Fatalf("expected records %#v, got %#v", tc.Expected, records)
+			}
+		})
+	}
+}
+
+func testRecordJSON(t *testing.T, r record) string {
+	t.Helper()
+
+	b, err := json.Marshal(r)
+	if err != nil {
+		t.Fatalf("did not expect an error but one was raised: %s", err)
+	}
+
+	return string(b)
+}
+
+// An SRV priority or weight of 0 is valid, and must still be sent.
+func TestSRVRecordJSON(t *testing.T) {
+	records := srvRecordSets([]interface{}{map[string]interface{}{
+		"priority": 0,
+		"weight":   0,
+		"port":     5060,
+		"target":   "sip.example.com.",
+	}})
+
+	expected := `{"priority":0,"weight":0,"port":5060,"target":"sip.example.com."}`
+	if got := testRecordJSON(t, records[0]); got != expected {
+		t.Fatalf("expected %s, got %s", expected, got)
+	}
+}
+
+// A NAPTR order or preference of 0, or an empty regexp, is valid, and must
+// still be sent.
+func TestNAPTRRecordJSON(t *testing.T) {
+	records := naptrRecordSets([]interface{}{map[string]interface{}{
+		"order":       0,
+		"preference":  0,
+		"flags":       "S",
+		"service":     "SIP+D2U",
+		"regexp":      "",
+		"replacement": "_sip._udp.example.com.",
+	}})
+
+	expected := `{"preference":0,"order":0,"flags":"S","service":"SIP+D2U","regexp":"","replacement":"_sip._udp.example.com."}`
+	if got := testRecordJSON(t, records[0]); got != expected {
+		t.Fatalf("expected %s, got %s", expected, got)
+	}
+}
+
+// A DS key tag of 0 is valid, and must still be sent.
+func TestDSRecordJSON(t *testing.T) {
+	records := dsRecordSets([]interface{}{map[string]interface{}{
+		"key_tag":     0,
+		"algorithm":   13,
+		"digest_type": 2,
+		"digest":      "E2D3C916F6DEEAC73294E8268FB5885044A833FC5459588F4A9184CFC41A5766",
+	}})
+
+	expected := `{"algorithm":13,"keytag":0,"digesttype":2,"digest":"e2d3c916f6deeac73294e8268fb5885044a833fc5459588f4a9184cfc41a5766"}`
+	if got := testRecordJSON(t, records[0]); got != expected {
+		t.Fatalf("expected %s, got %s", expected, got)
+	}
+}
+
+// VinylDNS carries SSHFP algorithm and fingerprint type as numbers.
+func TestSSHFPRecordJSON(t *testing.T) {
+	records := sshfpRecordSets([]interface{}{map[string]interface{}{
+		"algorithm":   4,
+		"type":        2,
+		"fingerprint": "1234567890ABCDEF1234567890ABCDEF1234567890ABCDEF1234567890ABCDEF",
+	}})
+
+	got, err := json.Marshal(records)
+	if err != nil {
+		t.Fatalf("did not expect an error but one was raised: %s", err)
+	}
+
+	expected := `[{"algorithm":4,"type":2,"fingerprint":"1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"}]`
+	if string(got) != expected {
+		t.Fatalf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestAddressHashCanonicalizesIPv6(t *testing.T) {
+	config := schema.NewSet(addressHash, []interface{}{"2001:DB8:0:0::1", "[2001:db8::2]"})
+	state := schema.NewSet(addressHash, []interface{}{"2001:db8::1", "2001:db8::2"})
+
+	if config.Difference(state).Len() != 0 || state.Difference(config).Len() != 0 {
+		t.Fatalf("expected equivalent IPv6 addresses to make equal sets, got %v and %v", config.List(), state.List())
+	}
+
+	records := addressRecordSets(stringSetToStringSlice(config))
+	sort.Slice(records, func(i, j int) bool { return records[i].Address < records[j].Address })
+
+	expected := []record{{Address: "2001:db8::1"}, {Address: "2001:db8::2"}}
+	if !reflect.DeepEqual(records, expected) {
+		t.Fatalf("expected records %#v, got %#v", expected, records)
+	}
+}
+
+func TestNormalizeTXT(t *testing.T) {
+	cases := map[string]struct {
+		Text     string
+		Expected string
+	}{
+		"plain":                  {Text: "v=spf1 -all", Expected: "v=spf1 -all"},
+		"empty":                  {Text: "", Expected: ""},
+		"quoted":                 {Text: `"v=spf1 -all"`, Expected: "v=spf1 -all"},
+		"several strings":        {Text: `"v=DKIM1; k=rsa; " "p=MIGf"`, Expected: "v=DKIM1; k=rsa; p=MIGf"},
+		"adjacent strings":       {Text: `"abc""def"`, Expected: `"abc""def"`},
+		"escaped quote":          {Text: `"say \"hi\""`, Expected: `say "hi"`},
+		"escaped backslash":      {Text: `"a\\b"`, Expected: `a\b`},
+		"decimal escape":         {Text: `"a\059b"`, Expected: "a;b"},
+		"surrounding space":      {Text: ` "abc" `, Expected: "abc"},
+		"unterminated":           {Text: `"abc`, Expected: `"abc`},
+		"trailing text":          {Text: `"abc" def`, Expected: `"abc" def`},
+		"quote inside plain":     {Text: `a "b" c`, Expected: `a "b" c`},
+		"trailing backslash":     {Text: `"abc\`, Expected: `"abc\`},
+		"decimal escape too big": {Text: `"a\999"`, Expected: `"a\999"`},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			if got := normalizeTXT(tc.Text); got != tc.Expected {
+				t.Fatalf("expected %q, got %q", tc.Expected, got)
+			}
+		})
+	}
+}
+
+func TestChunkTXT(t *testing.T) {
+	long := strings.Repeat("a", 254) + `"` + strings.Repeat("b", 300)
+
+	cases := map[string]struct {
+		Text     string
+		Expected string
+	}{
+		"short":   {Text: "v=spf1 -all", Expected: "v=spf1 -all"},
+		"255":     {Text: strings.Repeat("a", 255), Expected: strings.Repeat("a", 255)},
+		"256":     {Text: strings.Repeat("a", 256), Expected: `"` + strings.Repeat("a", 255) + `" "a"`},
+		"escapes": {Text: long, Expected: `"` + strings.Repeat("a", 254) + `\"" "` + strings.Repeat("b", 255) + `" "` + strings.Repeat("b", 45) + `"`},
+		"multi-byte at the boundary": {
+			Text:     strings.Repeat("a", 254) + "é" + strings.Repeat("b", 10),
+			Expected: `"` + strings.Repeat("a", 254) + `" "é` + strings.Repeat("b", 10) + `"`,
+		},
+		"multi-byte throughout": {
+			Text:     strings.Repeat("é", 200),
+			Expected: `"` + strings.Repeat("é", 127) + `" "` + strings.Repeat("é", 73) + `"`,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got := chunkTXT(tc.Text)
+			if got != tc.Expected {
+				t.Fatalf("expected %q, got %q", tc.Expected, got)
+			}
+			if !utf8.ValidString(got) {
+				t.Fatalf("expected %q to be valid UTF-8", got)
+			}
+			if normalizeTXT(got) != tc.Text {
+				t.Fatalf("expected %q to normalize back to %q, got %q", got, tc.Text, normalizeTXT(got))
+			}
+		})
+	}
+}
+
+func TestTXTHashNormalizesQuoting(t *testing.T) {
+	dkim := "v=DKIM1; k=rsa; p=" + strings.Repeat("A", 400)
+	config := schema.NewSet(txtHash, []interface{}{dkim})
+	state := schema.NewSet(txtHash, []interface{}{chunkTXT(dkim)})
+
+	if config.Difference(state).Len() != 0 || state.Difference(config).Len() != 0 {
+		t.Fatalf("expected a long TXT value and its chunked form to make equal sets")
+	}
+}
+
+func TestResourceVinylDNSRecordSetReadComputedAttributes(t *testing.T) {
+	meta := testAPIServerRoutes(t, map[string]string{
+		"/zones/zone-id/recordsets/rs-id": `{"recordSet": {
+			"id": "rs-id",
+			"zoneId": "zone-id",
+			"ownerGroupId": "group-id",
+			"name": "xn--bcher-kva",
+			"type": "A",
+			"status": "Active",
+			"created": "2026-01-02T03:04:05Z",
+			"updated": "2026-01-03T03:04:05Z",
+			"ttl": 300,
+			"account": "system",
+			"records": [{"address": "192.0.2.1"}],
+			"fqdn": "xn--bcher-kva.example.com."
+		}}`,
+		"/groups/group-id": `{"id": "group-id", "name": "dns-admins"}`,
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceVinylDNSRecordSet().Schema, map[string]interface{}{})
+	d.SetId("zone-id:rs-id")
+
+	if diags := resourceVinylDNSRecordSetRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("did not expect an error but one was raised: %#v", diags)
+	}
+
+	expected := map[string]string{
+		"name_ascii":       "xn--bcher-kva",
+		"name_unicode":     "bücher",
+		"fqdn":             "xn--bcher-kva.example.com.",
+		"status":           "Active",
+		"created":          "2026-01-02T03:04:05Z",
+		"updated":          "2026-01-03T03:04:05Z",
+		"account":          "system",
+		"owner_group_name": "dns-admins",
+	}
+	for k, v := range expected {
+		if got := d.Get(k).(string); got != v {
+			t.Fatalf("expected %s to be %q, got %q", k, v, got)
+		}
+	}
+}
+
+func TestResourceVinylDNSRecordSetDiffComputedAttributes(t *testing.T) {
+	dkim := "v=DKIM1; k=rsa; p=" + strings.Repeat("A", 400)
+	fingerprint := "123456789abcdef67890123456789abcdef67890"
+	sshfpHash := sshfpRecordHash(map[string]interface{}{"algorithm": 1, "type": 1, "fingerprint": fingerprint})
+
+	cases := map[string]struct {
+		Type          string
+		State         map[string]string
+		Raw           map[string]interface{}
+		ExpectUnknown bool
+	}{
+		"unchanged": {
+			Type:  "CNAME",
+			State: map[string]string{"ttl": "300", "record_cname": "www.example.com."},
+			Raw:   map[string]interface{}{"ttl": 300, "record_cname": "www.example.com."},
+		},
+		"ttl changed": {
+			Type:          "CNAME",
+			State:         map[string]string{"ttl": "300", "record_cname": "www.example.com."},
+			Raw:           map[string]interface{}{"ttl": 600, "record_cname": "www.example.com."},
+			ExpectUnknown: true,
+		},
+		"equivalent IPv6 address": {
+			Type: "AAAA",
+			State: map[string]string{
+				"ttl":                "300",
+				"record_addresses.#": "1",
+				fmt.Sprintf("record_addresses.%d", addressHash("2001:db8::1")): "2001:db8::1",
+			},
+			Raw: map[string]interface{}{"ttl": 300, "record_addresses": []interface{}{"2001:DB8:0:0::1"}},
+		},
+		"different IPv6 address": {
+			Type: "AAAA",
+			State: map[string]string{
+				"ttl":                "300",
+				"record_addresses.#": "1",
+				fmt.Sprintf("record_addresses.%d", addressHash("2001:db8::1")): "2001:db8::1",
+			},
+			Raw:           map[string]interface{}{"ttl": 300, "record_addresses": []interface{}{"2001:db8::2"}},
+			ExpectUnknown: true,
+		},
+		"chunked TXT value": {
+			Type: "TXT",
+			State: map[string]string{
+				"ttl":            "300",
+				"record_texts.#": "1",
+				fmt.Sprintf("record_texts.%d", txtHash(dkim)): chunkTXT(dkim),
+			},
+			Raw: map[string]interface{}{"ttl": 300, "record_texts": []interface{}{dkim}},
+		},
+		"SSHFP fingerprint case": {
+			Type: "SSHFP",
+			State: map[string]string{
+				"ttl":            "300",
+				"record_sshfp.#": "1",
+				fmt.Sprintf("record_sshfp.%d.algorithm", sshfpHash):   "1",
+				fmt.Sprintf("record_sshfp.%d.type", sshfpHash):        "1",
+				fmt.Sprintf("record_sshfp.%d.fingerprint", sshfpHash): fingerprint,
+			},
+			Raw: map[string]interface{}{"ttl": 300, "record_sshfp": []interface{}{map[string]interface{}{
+				"algorithm": 1, "type": 1, "fingerprint": strings.ToUpper(fingerprint),
+			}}},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			state := &terraform.InstanceState{
+				ID: "zone-id:rs-id",
+				Attributes: map[string]string{
+					"id":      "zone-id:rs-id",
+					"name":    "www",
+					"zone_id": "zone-id",
+					"type":    tc.Type,
+					"status":  "Active",
+					"updated": "2026-01-03T03:04:05Z",
+				},
+			}
+			for k, v := range tc.State {
+				state.Attributes[k] = v
+			}
+
+			raw := map[string]interface{}{"name": "www", "zone_id": "zone-id", "type": tc.Type}
+			for k, v := range tc.Raw {
+				raw[k] = v
+			}
+
+			diff, err := resourceVinylDNSRecordSet().Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), nil)
+			if err != nil {
+				t.Fatalf("did not expect an error but one was raised: %s", err)
+			}
+
+			for _, k := range []string{"status", "updated"} {
+				unknown := diff != nil && diff.Attributes[k] != nil && diff.Attributes[k].NewComputed
+				if unknown != tc.ExpectUnknown {
+					t.Fatalf("expected %s unknown to be %t, got %t", k, tc.ExpectUnknown, unknown)
+				}
+			}
+		})
+	}
+}
+
+func TestResourceVinylDNSRecordSetDiffProviderDefaults(t *testing.T) {
+	defaults := &providerMeta{defaultTTL: 3600, defaultOwnerGroupID: "default-group"}
+
+	cases := map[string]struct {
+		Meta          *providerMeta
+		State         map[string]string
+		Config        map[string]interface{}
+		ExpectTTL     string
+		ExpectOwner   string
+		ExpectUnknown bool
+		ExpectNoDiff  bool
+	}{
+		"defaults applied": {
+			Meta:        defaults,
+			ExpectTTL:   "3600",
+			ExpectOwner: "default-group",
+		},
+		"configured values win": {
+			Meta:        defaults,
+			Config:      map[string]interface{}{"ttl": 300, "owner_group_id": "my-group"},
+			ExpectTTL:   "300",
+			ExpectOwner: "my-group",
+		},
+		"no defaults": {
+			Meta:          &providerMeta{},
+			ExpectUnknown: true,
+		},
+		"server value matches default": {
+			Meta:         defaults,
+			State:        map[string]string{"ttl": "3600", "owner_group_id": "default-group"},
+			ExpectNoDiff: true,
+		},
+		"server value kept without defaults": {
+			Meta:         &providerMeta{},
+			State:        map[string]string{"ttl": "7200", "owner_group_id": "other-group"},
+			ExpectNoDiff: true,
+		},
+		"server value differs from default": {
+			Meta:        defaults,
+			State:       map[string]string{"ttl": "7200", "owner_group_id": "other-group"},
+			ExpectTTL:   "3600",
+			ExpectOwner: "default-group",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			config := map[string]interface{}{"name": "www", "zone_id": "zone-id", "type": "CNAME", "record_cname": "www.example.com."}
+			for k, v := range tc.Config {
+				config[k] = v
+			}
+
+			state := &terraform.InstanceState{RawConfig: testRawConfig(t, resourceVinylDNSRecordSet(), config)}
+			if tc.State != nil {
+				state.ID = "zone-id:rs-id"
+				state.Attributes = map[string]string{"id": state.ID}
+				for k, v := range config {
+					state.Attributes[k] = fmt.Sprint(v)
+				}
+				for k, v := range tc.State {
+					state.Attributes[k] = v
+				}
+			}
+
+			diff, err := resourceVinylDNSRecordSet().Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), tc.Meta)
+			if err != nil {
+				t.Fatalf("did not expect an error but one was raised: %s", err)
+			}
+
+			for k, expected := range map[string]string{"ttl": tc.ExpectTTL, "owner_group_id": tc.ExpectOwner} {
+				var attr *terraform.ResourceAttrDiff
+				if diff != nil {
+					attr = diff.Attributes[k]
+				}
+
+				switch {
+				case tc.ExpectNoDiff:
+					if attr != nil {
+						t.Fatalf("expected no diff for %s, got %#v", k, attr)
+					}
+				case tc.ExpectUnknown:
+					if attr == nil || !attr.NewComputed {
+						t.Fatalf("expected %s to be unknown, got %#v", k, attr)
+					}
+				default:
+					if attr == nil || attr.New != expected {
+						t.Fatalf("expected %s to be planned as %q, got %#v", k, expected, attr)
+					}
+				}
+			}
+		})
+	}
+}
+
+// testRawConfig returns the configuration raw as Terraform would send it,
+// with every attribute of r that raw leaves out set to null.
+func testRawConfig(t *testing.T, r *schema.Resource, raw map[string]interface{}) cty.Value {
+	t.Helper()
+
+	vals := map[string]cty.Value{}
+	for name, ty := range r.CoreConfigSchema().ImpliedType().AttributeTypes() {
+		v, ok := raw[name]
+		if !ok {
+			vals[name] = cty.NullVal(ty)
+			continue
+		}
+
+		switch v := v.(type) {
+		case string:
+			vals[name] = cty.StringVal(v)
+		case int:
+			vals[name] = cty.NumberIntVal(int64(v))
+		default:
+			t.Fatalf("unsupported raw config value %#v", v)
+		}
+	}
+
+	return cty.ObjectVal(vals)
+}
+
+func TestResourceVinylDNSRecordSetReadCNAMEWithoutRecords(t *testing.T) {
+	meta := testAPIServer(t, "/zones/zone-id/recordsets/rs-id", `{"recordSet": {
+		"id": "rs-id",
+		"zoneId": "zone-id",
+		"name": "www",
+		"type": "CNAME",
+		"ttl": 300,
+		"records": []
+	}}`)
+
+	d := schema.TestResourceDataRaw(t, resourceVinylDNSRecordSet().Schema, map[string]interface{}{})
+	d.SetId("zone-id:rs-id")
+
+	if diags := resourceVinylDNSRecordSetRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("did not expect an error but one was raised: %#v", diags)
+	}
+
+	if cname := d.Get("record_cname").(string); cname != "" {
+		t.Fatalf("expected an empty record_cname, got %q", cname)
+	}
+}
+
+func TestResourceVinylDNSRecordSetCustomizeDiff(t *testing.T) {
+	cases := map[string]struct {
+		Raw         map[string]interface{}
+		ExpectError string
+	}{
+		"A": {
+			Raw: map[string]interface{}{"type": "A", "record_addresses": []interface{}{"192.0.2.1"}},
+		},
+		"AAAA": {
+			Raw: map[string]interface{}{"type": "AAAA", "record_addresses": []interface{}{"2001:db8::1", "[2001:db8::2]"}},
+		},
+		"A with an IPv6 address": {
+			Raw:         map[string]interface{}{"type": "A", "record_addresses": []interface{}{"2001:db8::1"}},
+			ExpectError: `record_addresses: "2001:db8::1" is not an IPv4 address`,
+		},
+		"AAAA with an IPv4 address": {
+			Raw:         map[string]interface{}{"type": "AAAA", "record_addresses": []interface{}{"192.0.2.1"}},
+			ExpectError: `record_addresses: "192.0.2.1" is not an IPv6 address`,
+		},
+		"A with a hostname": {
+			Raw:         map[string]interface{}{"type": "A", "record_addresses": []interface{}{"www.example.com"}},
+			ExpectError: "is not an IPv4 address",
+		},
+		"lower case type": {
+			Raw: map[string]interface{}{"type": "cname", "record_cname": "www.example.com."},
+		},
+		"no records": {
+			Raw:         map[string]interface{}{"type": "A"},
+			ExpectError: "record_addresses: must be set for A records",
+		},
+		"empty cname": {
+			Raw:         map[string]interface{}{"type": "CNAME", "record_cname": ""},
+			ExpectError: "record_cname: must be set for CNAME records",
+		},
+		"records for another type": {
+			Raw:         map[string]interface{}{"type": "CNAME", "record_cname": "www.example.com.", "record_addresses": []interface{}{"192.0.2.1"}},
+			ExpectError: "record_addresses: cannot be set for CNAME records, which use record_cname",
+		},
+		"several cnames": {
+			Raw:         map[string]interface{}{"type": "CNAME", "record_cname": "a.example.com., b.example.com."},
+			ExpectError: "record_cname: a CNAME record set holds a single domain name",
+		},
+		"cname without trailing dot": {
+			Raw:         map[string]interface{}{"type": "CNAME", "record_cname": "www.example.com"},
+			ExpectError: "record_cname: must end in trailing '.'",
+		},
+		"ptrdname without trailing dot": {
+			Raw:         map[string]interface{}{"type": "PTR", "record_ptrdnames": []interface{}{"www.example.com"}},
+			ExpectError: `record_ptrdnames: "www.example.com" must end in trailing '.'`,
+		},
+		"sshfp": {
+			Raw: map[string]interface{}{"type": "SSHFP", "record_sshfp": []interface{}{
+				map[string]interface{}{"algorithm": 4, "type": 2, "fingerprint": strings.Repeat("ab", 32)},
+			}},
+		},
+		"sshfp with a SHA-256 fingerprint for SHA-1": {
+			Raw: map[string]interface{}{"type": "SSHFP", "record_sshfp": []interface{}{
+				map[string]interface{}{"algorithm": 4, "type": 1, "fingerprint": strings.Repeat("ab", 32)},
+			}},
+			ExpectError: "record_sshfp: fingerprint type 1 needs a fingerprint of 40 hex digits, got 64",
+		},
+		"ds": {
+			Raw: map[string]interface{}{"type": "DS", "record_ds": []interface{}{
+				map[string]interface{}{"key_tag": 60485, "algorithm": 5, "digest_type": 1, "digest": "2BB183AF5F22588179A53B0A98631FAD1A292118"},
+			}},
+		},
+		"ds with a short SHA-256 digest": {
+			Raw: map[string]interface{}{"type": "DS", "record_ds": []interface{}{
+				map[string]interface{}{"key_tag": 60485, "algorithm": 8, "digest_type": 2, "digest": "2BB183AF5F22588179A53B0A98631FAD1A292118"},
+			}},
+			ExpectError: "record_ds: digest type 2 needs a digest of 64 hex digits, got 40",
+		},
+		"ds with a SHA-384 digest": {
+			Raw: map[string]interface{}{"type": "DS", "record_ds": []interface{}{
+				map[string]interface{}{"key_tag": 60485, "algorithm": 14, "digest_type": 4, "digest": strings.Repeat("ab", 48)},
+			}},
+		},
+		"soa": {
+			Raw:         map[string]interface{}{"type": "SOA"},
+			ExpectError: "type: soa records are not currently supported by vinyldns",
+		},
+		"unknown type": {
+			Raw:         map[string]interface{}{"type": "HINFO"},
+			ExpectError: `type: "HINFO" is not a record type supported by this provider`,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			tc.Raw["name"] = "www"
+			tc.Raw["zone_id"] = "zone-id"
+
+			_, err := resourceVinylDNSRecordSet().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(tc.Raw), nil)
+			if tc.ExpectError == "" && err != nil {
+				t.Fatalf("did not expect an error but one was raised: %s", err)
+			}
+			if tc.ExpectError != "" && (err == nil || !strings.Contains(err.Error(), tc.ExpectError)) {
+				t.Fatalf("expected an error containing %q, got %v", tc.ExpectError, err)
+			}
+		})
+	}
+}
+
+func TestResourceVinylDNSRecordSetValidateTargets(t *testing.T) {
+	cases := map[string]struct {
+		Raw         map[string]interface{}
+		ExpectError bool
+	}{
+		"mx exchange": {
+			Raw: map[string]interface{}{"type": "MX", "record_mx": []interface{}{map[string]interface{}{"preference": 10, "exchange": "mail.example.com."}}},
+		},
+		"null mx": {
+			Raw: map[string]interface{}{"type": "MX", "record_mx": []interface{}{map[string]interface{}{"preference": 0, "exchange": "."}}},
+		},
+		"mx exchange without trailing dot": {
+			Raw:         map[string]interface{}{"type": "MX", "record_mx": []interface{}{map[string]interface{}{"preference": 10, "exchange": "mail.example.com"}}},
+			ExpectError: true,
+		},
+		"srv target without trailing dot": {
+			Raw:         map[string]interface{}{"type": "SRV", "record_srv": []interface{}{map[string]interface{}{"priority": 10, "weight": 60, "port": 5060, "target": "sip.example.com"}}},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			tc.Raw["name"] = "www"
+			tc.Raw["zone_id"] = "zone-id"
+
+			diags := resourceVinylDNSRecordSet().Validate(terraform.NewResourceConfigRaw(tc.Raw))
+			if tc.ExpectError && !diags.HasError() {
+				t.Fatalf("expected an error but one was not raised")
+			}
+			if !tc.ExpectError && diags.HasError() {
+				t.Fatalf("did not expect an error but one was raised: %#v", diags)
+			}
+		})
+	}
+}
+
+func TestCanonicalRecordName(t *testing.T) {
+	cases := map[string]struct {
+		Name        string
+		Expected    string
+		ExpectError string
+	}{
+		"relative":                   {Name: "www", Expected: "www"},
+		"absolute":                   {Name: "www.example.com.", Expected: "www"},
+		"absolute in other case":     {Name: "WWW.Example.COM.", Expected: "WWW"},
+		"absolute with several":      {Name: "_sip._tcp.example.com.", Expected: "_sip._tcp"},
+		"at sign":                    {Name: "@", Expected: "example.com."},
+		"zone name":                  {Name: "example.com.", Expected: "example.com."},
+		"zone name without dot":      {Name: "example.com", Expected: "example.com."},
+		"zone name in other case":    {Name: "EXAMPLE.com.", Expected: "example.com."},
+		"outside the zone":           {Name: "www.example.org.", ExpectError: `"www.example.org." is not within zone "example.com."`},
+		"zone name as a suffix only": {Name: "www.badexample.com.", ExpectError: "is not within zone"},
+		"unicode":                    {Name: "bücher", Expected: "xn--bcher-kva"},
+		"unicode absolute":           {Name: "Bücher.example.com.", Expected: "xn--bcher-kva"},
+		"invalid unicode":            {Name: "bü\u200dcher", ExpectError: "is not a valid internationalized domain name"},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got, err := canonicalRecordName(tc.Name, "example.com.")
+			if tc.ExpectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.ExpectError) {
+					t.Fatalf("expected an error containing %q, got %v", tc.ExpectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect an error but one was raised: %s", err)
+			}
+			if got != tc.Expected {
+				t.Fatalf("expected %q, got %q", tc.Expected, got)
+			}
+		})
+	}
+}
+
+func TestSuppressRecordNameDiff(t *testing.T) {
+	cases := map[string]struct {
+		ZoneName string
+		Old      string
+		New      string
+		Expected bool
+	}{
+		"equal":                  {ZoneName: "example.com.", Old: "www", New: "www", Expected: true},
+		"case":                   {ZoneName: "example.com.", Old: "www", New: "WWW", Expected: true},
+		"absolute":               {ZoneName: "example.com.", Old: "www", New: "www.example.com.", Expected: true},
+		"at sign":                {ZoneName: "example.com.", Old: "example.com.", New: "@", Expected: true},
+		"zone name":              {ZoneName: "example.com.", Old: "example.com.", New: "example.com", Expected: true},
+		"different":              {ZoneName: "example.com.", Old: "www", New: "mail", Expected: false},
+		"outside the zone":       {ZoneName: "example.com.", Old: "www", New: "www.example.org.", Expected: false},
+		"zone unknown":           {Old: "www", New: "WWW", Expected: true},
+		"unicode":                {ZoneName: "example.com.", Old: "xn--bcher-kva", New: "bücher", Expected: true},
+		"unicode absolute":       {ZoneName: "example.com.", Old: "xn--bcher-kva", New: "BÜCHER.example.com.", Expected: true},
+		"unicode, zone unknown":  {Old: "xn--bcher-kva", New: "bücher", Expected: true},
+		"unicode zone":           {ZoneName: "bücher.example.", Old: "xn--bcher-kva.example.", New: "@", Expected: true},
+		"absolute, zone unknown": {Old: "www", New: "www.example.com.", Expected: false},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceVinylDNSRecordSet().Schema, map[string]interface{}{"zone_name": tc.ZoneName})
+
+			if got := suppressRecordNameDiff("name", tc.Old, tc.New, d); got != tc.Expected {
+				t.Fatalf("expected %t, got %t", tc.Expected, got)
+			}
+		})
+	}
+}
+
+func TestRecordsAttributePath(t *testing.T) {
+	cases := map[string]struct {
+		Raw      map[string]interface{}
+		Expected cty.Path
+	}{
+		"cname without trailing dot": {
+			Raw:      map[string]interface{}{"type": "CNAME", "record_cname": "foo.example.com"},
+			Expected: cty.GetAttrPath("record_cname"),
+		},
+		"ptrdname without trailing dot": {
+			Raw:      map[string]interface{}{"type": "PTR", "record_ptrdnames": []interface{}{"foo.example.com"}},
+			Expected: cty.GetAttrPath("record_ptrdnames"),
+		},
+		"empty cname": {
+			Raw:      map[string]interface{}{"type": "CNAME"},
+			Expected: cty.GetAttrPath("record_cname"),
+		},
+		"soa": {
+			Raw:      map[string]interface{}{"type": "SOA"},
+			Expected: cty.GetAttrPath("type"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceVinylDNSRecordSet().Schema, tc.Raw)
+
+			_, diags := records(d)
+			if !diags.HasError() {
+				t.Fatalf("expected an error but one was not raised")
+			}
+			if !diags[0].AttributePath.Equals(tc.Expected) {
+				t.Fatalf("expected attribute path %#v, got %#v", tc.Expected, diags[0].AttributePath)
+			}
+		})
+	}
+}
+
+func TestDSRecordHashIgnoresDigestCase(t *testing.T) {
+	upper := map[string]interface{}{"key_tag": 60485, "algorithm": 5, "digest_type": 1, "digest": "2BB183AF5F22588179A53B0A98631FAD1A292118"}
+	lower := map[string]interface{}{"key_tag": 60485, "algorithm": 5, "digest_type": 1, "digest": "2bb183af5f22588179a53b0a98631fad1a292118"}
+	other := map[string]interface{}{"key_tag": 60486, "algorithm": 5, "digest_type": 1, "digest": "2bb183af5f22588179a53b0a98631fad1a292118"}
+
+	if dsRecordHash(upper) != dsRecordHash(lower) {
+		t.Fatalf("expected digests differing only in case to hash the same")
+	}
+	if dsRecordHash(lower) == dsRecordHash(other) {
+		t.Fatalf("expected records with different key tags to hash differently")
+	}
+}
+
+func TestSSHFPRecordHashIgnoresFingerprintCase(t *testing.T) {
+	upper := map[string]interface{}{"algorithm": 4, "type": 2, "fingerprint": "ABCDEF1234567890ABCDEF1234567890ABCDEF12"}
+	lower := map[string]interface{}{"algorithm": 4, "type": 2, "fingerprint": "abcdef1234567890abcdef1234567890abcdef12"}
+	other := map[string]interface{}{"algorithm": 4, "type": 1, "fingerprint": "abcdef1234567890abcdef1234567890abcdef12"}
+
+	if sshfpRecordHash(upper) != sshfpRecordHash(lower) {
+		t.Fatalf("expected fingerprints differing only in case to hash the same")
+	}
+	if sshfpRecordHash(lower) == sshfpRecordHash(other) {
+		t.Fatalf("expected records with different fingerprint types to hash differently")
+	}
+}
+
 func testAccVinylDNSRecordSetImportARecordStateCheck(s []*terraform.InstanceState) error {
 	if len(s) != 1 {
 		return fmt.Errorf("expected 1 state: %#v", s)
@@ -286,7 +1324,7 @@ func testAccVinylDNSRecordSetImportPTRRecordStateCheck(s []*terraform.InstanceSt
 }
 
 func testAccVinylDNSRecordSetDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*vinyldns.Client)
+	client := testAccProvider.Meta().(*providerMeta).client
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "vinyldns_record_set" {
@@ -298,7 +1336,7 @@ func testAccVinylDNSRecordSetDestroy(s *terraform.State) error {
 		}
 
 		// Try to find the record set
-		_, err = client.RecordSet(zID, rsID)
+		_, err = getRecordSet(context.Background(), client, zID, rsID)
 		if err == nil {
 			return fmt.Errorf("RecordSet %s still exists in zone %s", rsID, zID)
 		}
@@ -318,12 +1356,12 @@ func testAccCheckVinylDNSRecordSetExists(n string) resource.TestCheckFunc {
 			return fmt.Errorf("No RecordSet ID is set")
 		}
 
-		client := testAccProvider.Meta().(*vinyldns.Client)
+		client := testAccProvider.Meta().(*providerMeta).client
 		zID, rsID, err := parseTwoPartID(rs.Primary.ID)
 		if err != nil {
 			return err
 		}
-		readRs, err := client.RecordSet(zID, rsID)
+		readRs, err := getRecordSet(context.Background(), client, zID, rsID)
 		if err != nil {
 			return err
 		}
@@ -336,6 +1374,31 @@ func testAccCheckVinylDNSRecordSetExists(n string) resource.TestCheckFunc {
 	}
 }
 
+func testAccCheckVinylDNSRecordSetStoredText(n, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found %s", n)
+		}
+
+		client := testAccProvider.Meta().(*providerMeta).client
+		zID, rsID, err := parseTwoPartID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		readRs, err := getRecordSet(context.Background(), client, zID, rsID)
+		if err != nil {
+			return err
+		}
+
+		if len(readRs.Records) != 1 || readRs.Records[0].Text != expected {
+			return fmt.Errorf("expected VinylDNS to store the text %q, got %#v", expected, readRs.Records)
+		}
+
+		return nil
+	}
+}
+
 func testRecordInZone(n string, s *terraform.State, expectedZone string) error {
 	rs, ok := s.RootModule().Resources[n]
 	if !ok {
@@ -346,12 +1409,12 @@ func testRecordInZone(n string, s *terraform.State, expectedZone string) error {
 		return fmt.Errorf("No RecordSet ID is set")
 	}
 
-	client := testAccProvider.Meta().(*vinyldns.Client)
+	client := testAccProvider.Meta().(*providerMeta).client
 	zID, rsID, err := parseTwoPartID(rs.Primary.ID)
 	if err != nil {
 		return err
 	}
-	readRs, err := client.RecordSet(zID, rsID)
+	readRs, err := getRecordSet(context.Background(), client, zID, rsID)
 	if err != nil {
 		return err
 	}
@@ -448,6 +1511,88 @@ resource "vinyldns_record_set" "test_ns_record_set" {
 	]
 }
 
+resource "vinyldns_record_set" "test_mx_record_set" {
+	name = "mx-terraformtestrecordset"
+	zone_id = "${vinyldns_zone.test_zone.id}"
+	type = "MX"
+	ttl = 6000
+	record_mx {
+		preference = 10
+		exchange = "mail1.system-test."
+	}
+	record_mx {
+		preference = 20
+		exchange = "mail2.system-test."
+	}
+	depends_on = [
+		"vinyldns_zone.test_zone"
+	]
+}
+
+resource "vinyldns_record_set" "test_srv_record_set" {
+	name = "_sip._tcp.srv-terraformtestrecordset"
+	zone_id = "${vinyldns_zone.test_zone.id}"
+	type = "SRV"
+	ttl = 6000
+	record_srv {
+		priority = 10
+		weight = 60
+		port = 5060
+		target = "sip.system-test."
+	}
+	depends_on = [
+		"vinyldns_zone.test_zone"
+	]
+}
+
+resource "vinyldns_record_set" "test_sshfp_record_set" {
+	name = "sshfp-terraformtestrecordset"
+	zone_id = "${vinyldns_zone.test_zone.id}"
+	type = "SSHFP"
+	ttl = 6000
+	record_sshfp {
+		algorithm = 4
+		type = 2
+		fingerprint = "1234567890ABCDEF1234567890ABCDEF1234567890ABCDEF1234567890ABCDEF"
+	}
+	depends_on = [
+		"vinyldns_zone.test_zone"
+	]
+}
+
+resource "vinyldns_record_set" "test_naptr_record_set" {
+	name = "naptr-terraformtestrecordset"
+	zone_id = "${vinyldns_zone.test_zone.id}"
+	type = "NAPTR"
+	ttl = 6000
+	record_naptr {
+		order = 100
+		preference = 10
+		flags = "U"
+		service = "E2U+sip"
+		regexp = "!^.*$!sip:info@system-test!"
+		replacement = "."
+	}
+	depends_on = [
+		"vinyldns_zone.test_zone"
+	]
+}
+
+# VinylDNS only accepts a DS record set where an NS record set delegates
+# the same name.
+resource "vinyldns_record_set" "test_ds_record_set" {
+	name = "${vinyldns_record_set.test_ns_record_set.name}"
+	zone_id = "${vinyldns_zone.test_zone.id}"
+	type = "DS"
+	ttl = 6000
+	record_ds {
+		key_tag = 60485
+		algorithm = 5
+		digest_type = 1
+		digest = "2BB183AF5F22588179A53B0A98631FAD1A292118"
+	}
+}
+
 resource "vinyldns_zone" "test_reverse_zone" {
 	name = "2.0.192.in-addr.arpa."
 	email = "foo@bar.com"
@@ -499,3 +1644,29 @@ resource "vinyldns_record_set" "test_a_record_set" {
 	record_addresses = ["127.0.0.1", "127.0.0.1"]
 }`, z)
 }
+
+func testAccVinylDNSRecordSetConfigLongTXT() string {
+	return fmt.Sprintf(`
+resource "vinyldns_group" "test_group" {
+	name = "terraformtestgroup"
+	description = "some description"
+	email = "tftest@tf.com"
+	member_ids = ["ok"]
+	admin_ids = ["ok"]
+}
+
+resource "vinyldns_zone" "test_zone" {
+	name = "system-test."
+	email = "foo@bar.com"
+	admin_group_id = "${vinyldns_group.test_group.id}"
+}
+
+resource "vinyldns_record_set" "test_long_txt_record_set" {
+	name = "long-txt-terraformtestrecordset"
+	zone_id = "${vinyldns_zone.test_zone.id}"
+	type = "TXT"
+	ttl = 6000
+	record_texts = [%q]
+}
+`, testAccLongTXT)
+}
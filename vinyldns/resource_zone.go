@@ -13,12 +13,13 @@ limitations under the License.
 package vinyldns
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"log"
 	"net/http"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vinyldns/go-vinyldns/vinyldns"
@@ -27,26 +28,51 @@ import (
 func resourceVinylDNSZone() *schema.Resource {
 	return &schema.Resource{
 		SchemaVersion: 1,
-		Create:        resourceVinylDNSZoneCreate,
-		Read:          resourceVinylDNSZoneRead,
-		Update:        resourceVinylDNSZoneUpdate,
-		Delete:        resourceVinylDNSZoneDelete,
+		CreateContext: resourceVinylDNSZoneCreate,
+		ReadContext:   resourceVinylDNSZoneRead,
+		UpdateContext: resourceVinylDNSZoneUpdate,
+		DeleteContext: resourceVinylDNSZoneDelete,
+		CustomizeDiff: resourceVinylDNSZoneCustomizeDiff,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: resourceVinylDNSZoneImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateZoneName,
+				DiffSuppressFunc: suppressZoneNameDiff,
+			},
+			"name_ascii": &schema.Schema{
 				Type:     schema.TypeString,
-				Required: true,
+				Computed: true,
+			},
+			"name_unicode": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
 			},
 			"email": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
 			},
 			"admin_group_id": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"admin_group_id", "admin_group_name"},
+			},
+			"admin_group_name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"admin_group_id", "admin_group_name"},
 			},
 			"status": &schema.Schema{
 				Type:     schema.TypeString,
@@ -110,12 +136,55 @@ func resourceVinylDNSZone() *schema.Resource {
 	}
 }
 
-func resourceVinylDNSZoneCreate(d *schema.ResourceData, meta interface{}) error {
+// resourceVinylDNSZoneCustomizeDiff resolves admin_group_name, when it is
+// configured in place of admin_group_id, to the ID of the group it names.
+func resourceVinylDNSZoneCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.HasChange("name") {
+		for _, k := range []string{"name_ascii", "name_unicode"} {
+			if err := d.SetNewComputed(k); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, ok := meta.(*providerMeta); !ok {
+		return nil
+	}
+
+	if !configNull(d, "admin_group_id") {
+		if d.HasChange("admin_group_id") {
+			return d.SetNewComputed("admin_group_name")
+		}
+
+		return nil
+	}
+
+	if configNull(d, "admin_group_name") {
+		return nil
+	}
+
+	if !d.NewValueKnown("admin_group_name") {
+		return d.SetNewComputed("admin_group_id")
+	}
+
+	group, err := groupByName(meta, d.Get("admin_group_name").(string))
+	if err != nil {
+		return fmt.Errorf("admin_group_name: %s", err)
+	}
+
+	return d.SetNew("admin_group_id", group.ID)
+}
+
+func resourceVinylDNSZoneCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := zoneLookupIDs(d, meta); diags.HasError() {
+		return diags
+	}
+
 	name := d.Get("name").(string)
 	log.Printf("[INFO] Creating vinyldns zone: %s", name)
-	change, err := meta.(*vinyldns.Client).ZoneCreate(zone(d))
+	change, err := meta.(*providerMeta).client.ZoneCreate(zone(d))
 	if err != nil {
-		return err
+		return errorDiagnostics(fmt.Sprintf("creating zone (%s)", name), err)
 	}
 
 	log.Printf("[INFO] Setting *schema.ResourceData zone ID to: %s", change.Zone.ID)
@@ -124,17 +193,17 @@ func resourceVinylDNSZoneCreate(d *schema.ResourceData, meta interface{}) error
 
 	log.Printf("[INFO] *schema.ResourceData ID: %s", d.Id())
 
-	err = waitUntilZoneCreated(d, meta)
+	err = waitUntilZoneCreated(ctx, d, meta, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
-		return err
+		return errorDiagnostics(fmt.Sprintf("waiting for zone (%s) to be created", d.Id()), err)
 	}
 
-	return resourceVinylDNSZoneRead(d, meta)
+	return resourceVinylDNSZoneRead(ctx, d, meta)
 }
 
-func resourceVinylDNSZoneRead(d *schema.ResourceData, meta interface{}) error {
+func resourceVinylDNSZoneRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[INFO] Reading vinyldns zone: %s", d.Id())
-	zone, err := meta.(*vinyldns.Client).Zone(d.Id())
+	zone, err := meta.(*providerMeta).client.Zone(d.Id())
 	if err != nil {
 		if vErr, ok := err.(*vinyldns.Error); ok {
 			if vErr.ResponseCode == http.StatusNotFound {
@@ -145,15 +214,24 @@ func resourceVinylDNSZoneRead(d *schema.ResourceData, meta interface{}) error {
 				return nil
 			}
 
-			return fmt.Errorf("error reading zone (%s): %s", d.Id(), err)
+			return errorDiagnostics(fmt.Sprintf("reading zone (%s)", d.Id()), err)
 		}
 
-		return fmt.Errorf("error reading zone (%s): %s", d.Id(), err)
+		return errorDiagnostics(fmt.Sprintf("reading zone (%s)", d.Id()), err)
 	}
 
 	d.Set("name", zone.Name)
+	d.Set("name_ascii", zone.Name)
+	d.Set("name_unicode", unicodeName(zone.Name))
 	d.Set("email", zone.Email)
 	d.Set("admin_group_id", zone.AdminGroupID)
+
+	adminGroupName, diags := groupName(meta, zone.AdminGroupID)
+	if diags.HasError() {
+		return diags
+	}
+	d.Set("admin_group_name", adminGroupName)
+
 	d.Set("status", zone.Status)
 	d.Set("shared", zone.Shared)
 	d.Set("created", zone.Created)
@@ -164,7 +242,7 @@ func resourceVinylDNSZoneRead(d *schema.ResourceData, meta interface{}) error {
 		acls := buildACLRules(zone.ACL)
 
 		if err := d.Set("acl_rule", acls); err != nil {
-			return fmt.Errorf("error setting ACL rule for zone %s: %s", d.Id(), err)
+			return diag.Errorf("error setting ACL rule for zone %s: %s", d.Id(), err)
 		}
 	}
 
@@ -193,19 +271,23 @@ func resourceVinylDNSZoneRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceVinylDNSZoneUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceVinylDNSZoneUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[INFO] Updating vinyldns zone: %s", d.Id())
-	change, err := meta.(*vinyldns.Client).ZoneUpdate(zone(d))
+	if diags := zoneLookupIDs(d, meta); diags.HasError() {
+		return diags
+	}
+
+	change, err := meta.(*providerMeta).client.ZoneUpdate(zone(d))
 	if err != nil {
-		return err
+		return errorDiagnostics(fmt.Sprintf("updating zone (%s)", d.Id()), err)
 	}
 
-	err = waitUntilZoneChangeDeployed(d, meta, change.ID)
+	err = waitUntilZoneChangeDeployed(ctx, d, meta, change.ID, d.Timeout(schema.TimeoutUpdate))
 	if err != nil {
-		return err
+		return errorDiagnostics(fmt.Sprintf("waiting for zone (%s) to be updated", d.Id()), err)
 	}
 
-	return resourceVinylDNSZoneRead(d, meta)
+	return resourceVinylDNSZoneRead(ctx, d, meta)
 }
 
 func zoneConnection(d *schema.ResourceData) *vinyldns.ZoneConnection {
@@ -240,10 +322,10 @@ func transferConnection(d *schema.ResourceData) *vinyldns.ZoneConnection {
 	return &vinyldns.ZoneConnection{}
 }
 
-func resourceVinylDNSZoneDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceVinylDNSZoneDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[INFO] Deleting vinyldns zone: %s", d.Id())
 
-	_, err := meta.(*vinyldns.Client).ZoneDelete(d.Id())
+	_, err := meta.(*providerMeta).client.ZoneDelete(d.Id())
 	if err != nil {
 		if vErr, ok := err.(*vinyldns.Error); ok {
 			if vErr.ResponseCode == http.StatusNotFound {
@@ -252,46 +334,44 @@ func resourceVinylDNSZoneDelete(d *schema.ResourceData, meta interface{}) error
 				return nil
 			}
 
-			return fmt.Errorf("error deleting zone (%s): %s", d.Id(), err)
+			return errorDiagnostics(fmt.Sprintf("deleting zone (%s)", d.Id()), err)
 		}
 
-		return fmt.Errorf("error deleting zone (%s): %s", d.Id(), err)
+		return errorDiagnostics(fmt.Sprintf("deleting zone (%s)", d.Id()), err)
 	}
 
-	err = waitUntilZoneDeleted(d, meta, d.Id())
+	err = waitUntilZoneDeleted(ctx, d, meta, d.Id(), d.Timeout(schema.TimeoutDelete))
 	if err != nil {
-		return err
+		return errorDiagnostics(fmt.Sprintf("waiting for zone (%s) to be deleted", d.Id()), err)
 	}
 
 	return nil
 }
 
-func waitUntilZoneChangeDeployed(d *schema.ResourceData, meta interface{}, changeID string) error {
-	stateConf := &resource.StateChangeConf{
-		Pending:      []string{"Pending", ""},
-		Target:       []string{"Synced"},
-		Refresh:      zoneStateRefreshFunc(d, meta, changeID),
-		Timeout:      30 * time.Minute,
-		Delay:        500 * time.Millisecond,
-		MinTimeout:   15 * time.Second,
-		PollInterval: 500 * time.Millisecond,
+func waitUntilZoneChangeDeployed(ctx context.Context, d *schema.ResourceData, meta interface{}, changeID string, timeout time.Duration) error {
+	waiter := &changeWaiter{
+		Pending: []string{"Pending", ""},
+		Target:  []string{"Synced"},
+		Refresh: zoneStateRefreshFunc(d, meta, changeID),
+		Timeout: timeout,
+		Polling: meta.(*providerMeta).polling,
 	}
 
-	_, err := stateConf.WaitForState()
+	_, err := waiter.WaitForStateContext(ctx)
 	return err
 }
 
 func zoneStateRefreshFunc(d *schema.ResourceData, meta interface{}, changeID string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		log.Printf("[INFO] waiting for Complete status of zone %v (ID %s) for change ID %s", d.Get("name"), d.Id(), changeID)
-		zc, err := meta.(*vinyldns.Client).ZoneChange(d.Id(), changeID)
+		zc, err := meta.(*providerMeta).client.ZoneChange(d.Id(), changeID)
 		if err != nil {
 			log.Printf("[ERROR] %#v", err)
 			return nil, "", err
 		}
 		if zc.Status == "Failed" {
-			err = errors.New("zone status Failed")
-			log.Printf("[ERROR] zone status Failed: %#v", err)
+			err = zoneChangeFailedError(zc)
+			log.Printf("[ERROR] %s", err)
 			return zc, zc.Status, err
 		}
 
@@ -299,18 +379,16 @@ func zoneStateRefreshFunc(d *schema.ResourceData, meta interface{}, changeID str
 	}
 }
 
-func waitUntilZoneDeleted(d *schema.ResourceData, meta interface{}, zoneID string) error {
-	stateConf := &resource.StateChangeConf{
-		Pending:      []string{"Pending"},
-		Target:       []string{"Deleted"},
-		Refresh:      zoneDeletedStateRefreshFunc(d, meta, zoneID),
-		Timeout:      30 * time.Minute,
-		Delay:        500 * time.Millisecond,
-		MinTimeout:   15 * time.Second,
-		PollInterval: 500 * time.Millisecond,
+func waitUntilZoneDeleted(ctx context.Context, d *schema.ResourceData, meta interface{}, zoneID string, timeout time.Duration) error {
+	waiter := &changeWaiter{
+		Pending: []string{"Pending"},
+		Target:  []string{"Deleted"},
+		Refresh: zoneDeletedStateRefreshFunc(d, meta, zoneID),
+		Timeout: timeout,
+		Polling: meta.(*providerMeta).polling,
 	}
 
-	_, err := stateConf.WaitForState()
+	_, err := waiter.WaitForStateContext(ctx)
 	return err
 }
 
@@ -319,7 +397,7 @@ func zoneDeletedStateRefreshFunc(d *schema.ResourceData, meta interface{}, zoneI
 		state := "Pending"
 
 		log.Printf("[INFO] waiting for successful deletion of %v, %s", d.Get("name"), d.Id())
-		exists, err := meta.(*vinyldns.Client).ZoneExists(d.Id())
+		exists, err := meta.(*providerMeta).client.ZoneExists(d.Id())
 		if err != nil {
 			log.Printf("[ERROR] %#v", err)
 			return nil, "", err
@@ -333,18 +411,16 @@ func zoneDeletedStateRefreshFunc(d *schema.ResourceData, meta interface{}, zoneI
 	}
 }
 
-func waitUntilZoneCreated(d *schema.ResourceData, meta interface{}) error {
-	stateConf := &resource.StateChangeConf{
-		Pending:      []string{"Pending"},
-		Target:       []string{"Created"},
-		Refresh:      zoneCreatedStateRefreshFunc(d, meta),
-		Timeout:      30 * time.Minute,
-		Delay:        500 * time.Millisecond,
-		MinTimeout:   15 * time.Second,
-		PollInterval: 500 * time.Millisecond,
+func waitUntilZoneCreated(ctx context.Context, d *schema.ResourceData, meta interface{}, timeout time.Duration) error {
+	waiter := &changeWaiter{
+		Pending: []string{"Pending"},
+		Target:  []string{"Created"},
+		Refresh: zoneCreatedStateRefreshFunc(d, meta),
+		Timeout: timeout,
+		Polling: meta.(*providerMeta).polling,
 	}
 
-	_, err := stateConf.WaitForState()
+	_, err := waiter.WaitForStateContext(ctx)
 	return err
 }
 
@@ -353,7 +429,7 @@ func zoneCreatedStateRefreshFunc(d *schema.ResourceData, meta interface{}) resou
 		state := "Pending"
 
 		log.Printf("[INFO] waiting for successful creation of %v, %s", d.Get("name"), d.Id())
-		exists, err := meta.(*vinyldns.Client).ZoneExists(d.Id())
+		exists, err := meta.(*providerMeta).client.ZoneExists(d.Id())
 		if err != nil {
 			log.Printf("[ERROR] %#v", err)
 			return nil, "", err
@@ -399,8 +475,13 @@ func connectionSchema() *schema.Schema {
 }
 
 func zone(d *schema.ResourceData) *vinyldns.Zone {
+	name := d.Get("name").(string)
+	if ascii, err := asciiName(name); err == nil {
+		name = ascii
+	}
+
 	zone := &vinyldns.Zone{
-		Name:         d.Get("name").(string),
+		Name:         name,
 		Email:        d.Get("email").(string),
 		AdminGroupID: d.Get("admin_group_id").(string),
 		ACL: &vinyldns.ZoneACL{
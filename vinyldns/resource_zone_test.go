@@ -20,7 +20,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	"github.com/vinyldns/go-vinyldns/vinyldns"
 )
 
 const (
@@ -309,7 +308,7 @@ func testAccVinylDNSZoneWithACLImportStateCheck(s []*terraform.InstanceState) er
 }
 
 func testAccVinylDNSZoneDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*vinyldns.Client)
+	client := testAccProvider.Meta().(*providerMeta).client
 
 	for _, rs := range s.RootModule().Resources {
 		log.Printf("[INFO] testing zone destruction; rs.Type: %s", rs.Type)
@@ -342,7 +341,7 @@ func testAccCheckVinylDNSZoneBasicExists(n, email string) resource.TestCheckFunc
 			return fmt.Errorf("No Zone ID is set")
 		}
 
-		client := testAccProvider.Meta().(*vinyldns.Client)
+		client := testAccProvider.Meta().(*providerMeta).client
 
 		readZone, err := client.Zone(rs.Primary.ID)
 		if err != nil {
@@ -374,7 +373,7 @@ func testAccCheckVinylDNSZoneWithACLExists(n, email, recType string) resource.Te
 			return fmt.Errorf("No Zone ID is set")
 		}
 
-		client := testAccProvider.Meta().(*vinyldns.Client)
+		client := testAccProvider.Meta().(*providerMeta).client
 
 		readZone, err := client.Zone(rs.Primary.ID)
 		if err != nil {
@@ -419,7 +418,7 @@ func testAccCheckVinylDNSZoneWithConnectionExists(n, email string) resource.Test
 			return fmt.Errorf("No Zone ID is set")
 		}
 
-		client := testAccProvider.Meta().(*vinyldns.Client)
+		client := testAccProvider.Meta().(*providerMeta).client
 
 		readZone, err := client.Zone(rs.Primary.ID)
 		if err != nil {
@@ -466,7 +465,7 @@ func testAccCheckVinylDNSZoneWithTransferConnectionExists(n, email string) resou
 			return fmt.Errorf("No Zone ID is set")
 		}
 
-		client := testAccProvider.Meta().(*vinyldns.Client)
+		client := testAccProvider.Meta().(*providerMeta).client
 
 		readZone, err := client.Zone(rs.Primary.ID)
 		if err != nil {
@@ -513,7 +512,7 @@ func testAccCheckVinylDNSZoneWithACLAndNoRecordTypesExists(n, email string) reso
 			return fmt.Errorf("No Zone ID is set")
 		}
 
-		client := testAccProvider.Meta().(*vinyldns.Client)
+		client := testAccProvider.Meta().(*providerMeta).client
 
 		readZone, err := client.Zone(rs.Primary.ID)
 		if err != nil {
@@ -0,0 +1,161 @@
+/*
+Copyright 2018 Comcast Cable Communications Management, LLC
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vinyldns
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const defaultMaxRetries = 3
+
+func defaultRetryBackoff() pollingStrategy {
+	return pollingStrategy{
+		InitialInterval: 1 * time.Second,
+		MaxInterval:     30 * time.Second,
+		Multiplier:      2,
+		Jitter:          0.2,
+	}
+}
+
+// retryTransport retries VinylDNS API requests that failed because the API
+// was throttling or temporarily unavailable.
+//
+// Requests with idempotent methods are retried on 429, 502, 503 and 504
+// responses and on network errors. POST requests create zones, record sets
+// and groups, so they are only retried when the API cannot have acted on
+// them: on 429 responses and when the connection could not be established.
+//
+// A Retry-After header sets the wait, up to the backoff's MaxInterval. The
+// wait ends early if the request's context is done. Record set requests
+// carry their operation's context and group requests are bounded by their
+// timeouts, but the go-vinyldns client's other calls take no context, so
+// their waits cannot be cancelled.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	backoff    pollingStrategy
+
+	// sleep waits for d or until the request is cancelled; it is only
+	// overridden by tests.
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+func newRetryTransport(next http.RoundTripper, maxRetries int, backoff pollingStrategy) *retryTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &retryTransport{
+		next:       next,
+		maxRetries: maxRetries,
+		backoff:    backoff,
+		sleep:      sleepContext,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	attemptReq := req
+
+	for attempt := 0; ; attempt++ {
+		resp, err := t.next.RoundTrip(attemptReq)
+		if attempt >= t.maxRetries || !retryable(req.Method, resp, err) {
+			return resp, err
+		}
+
+		if req.Body != nil && req.GetBody == nil {
+			// the body has been consumed and cannot be replayed
+			return resp, err
+		}
+
+		wait := t.backoff.interval(attempt)
+		if resp != nil {
+			if ra, ok := retryAfter(resp); ok {
+				wait = ra
+				if wait > t.backoff.MaxInterval {
+					wait = t.backoff.MaxInterval
+				}
+			}
+
+			log.Printf("[WARN] %s %s returned %d; retrying in %s (%d/%d)", req.Method, req.URL, resp.StatusCode, wait, attempt+1, t.maxRetries)
+
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		} else {
+			log.Printf("[WARN] %s %s failed: %s; retrying in %s (%d/%d)", req.Method, req.URL, err, wait, attempt+1, t.maxRetries)
+		}
+
+		if err := t.sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+
+		attemptReq = req.Clone(req.Context())
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq.Body = body
+		}
+	}
+}
+
+func retryable(method string, resp *http.Response, err error) bool {
+	idempotent := method != http.MethodPost && method != http.MethodPatch
+
+	if err != nil {
+		if idempotent {
+			return true
+		}
+
+		// a request that never reached the API is always safe to repeat
+		var opErr *net.OpError
+		return errors.As(err, &opErr) && opErr.Op == "dial"
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent
+	}
+
+	return false
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if when, err := http.ParseTime(v); err == nil {
+		if d := time.Until(when); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
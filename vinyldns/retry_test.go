@@ -0,0 +1,347 @@
+/*
+Copyright 2018 Comcast Cable Communications Management, LLC
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vinyldns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vinyldns/go-vinyldns/vinyldns"
+)
+
+// flakyServer responds with each of codes in turn and then with 200 and body.
+type flakyServer struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	codes  []int
+	calls  int
+	bodies []string
+}
+
+func newFlakyServer(t *testing.T, body string, codes ...int) *flakyServer {
+	f := &flakyServer{codes: codes}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+
+		f.mu.Lock()
+		call := f.calls
+		f.calls++
+		f.bodies = append(f.bodies, string(b))
+		f.mu.Unlock()
+
+		if call < len(f.codes) {
+			if f.codes[call] == http.StatusTooManyRequests {
+				w.Header().Set("Retry-After", "0")
+			}
+			w.WriteHeader(f.codes[call])
+			fmt.Fprint(w, `{"errors":["flaky"]}`)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(f.Close)
+
+	return f
+}
+
+func (f *flakyServer) Calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.calls
+}
+
+func testRetryClient(host string, maxRetries int) *vinyldns.Client {
+	client := vinyldns.NewClient(vinyldns.ClientConfiguration{
+		AccessKey: "accessKey",
+		SecretKey: "secretKey",
+		Host:      host,
+	})
+
+	rt := newRetryTransport(nil, maxRetries, defaultRetryBackoff())
+	rt.sleep = func(context.Context, time.Duration) error { return nil }
+	client.HTTPClient.Transport = rt
+
+	return client
+}
+
+func TestRetryTransportIdempotentRequests(t *testing.T) {
+	cases := map[string]struct {
+		Codes       []int
+		MaxRetries  int
+		ExpectCalls int
+		ExpectCode  int
+	}{
+		"succeeds first time": {
+			MaxRetries:  3,
+			ExpectCalls: 1,
+		},
+		"recovers from transient failures": {
+			Codes:       []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusGatewayTimeout},
+			MaxRetries:  3,
+			ExpectCalls: 4,
+		},
+		"recovers from throttling": {
+			Codes:       []int{http.StatusTooManyRequests},
+			MaxRetries:  3,
+			ExpectCalls: 2,
+		},
+		"gives up after max_retries": {
+			Codes:       []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+			MaxRetries:  2,
+			ExpectCalls: 3,
+			ExpectCode:  http.StatusServiceUnavailable,
+		},
+		"retries disabled": {
+			Codes:       []int{http.StatusServiceUnavailable},
+			MaxRetries:  0,
+			ExpectCalls: 1,
+			ExpectCode:  http.StatusServiceUnavailable,
+		},
+		"does not retry client errors": {
+			Codes:       []int{http.StatusBadRequest},
+			MaxRetries:  3,
+			ExpectCalls: 1,
+			ExpectCode:  http.StatusBadRequest,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			server := newFlakyServer(t, `{"zone":{"id":"zone-id","name":"ok."}}`, tc.Codes...)
+			client := testRetryClient(server.URL, tc.MaxRetries)
+
+			zone, err := client.Zone("zone-id")
+			if tc.ExpectCode != 0 {
+				vErr, ok := err.(*vinyldns.Error)
+				if !ok {
+					t.Fatalf("expected a *vinyldns.Error, got %#v", err)
+				}
+				if vErr.ResponseCode != tc.ExpectCode {
+					t.Fatalf("expected response code %d, got %d", tc.ExpectCode, vErr.ResponseCode)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("did not expect an error but one was raised: %s", err)
+				}
+				if zone.ID != "zone-id" {
+					t.Fatalf("expected zone ID zone-id, got %s", zone.ID)
+				}
+			}
+
+			if server.Calls() != tc.ExpectCalls {
+				t.Fatalf("expected %d calls, got %d", tc.ExpectCalls, server.Calls())
+			}
+		})
+	}
+}
+
+func TestRetryTransportCapsRetryAfter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	client := testRetryClient(server.URL, 1)
+	rt := client.HTTPClient.Transport.(*retryTransport)
+
+	var waits []time.Duration
+	rt.sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	client.Zone("zone-id")
+
+	if len(waits) != 1 || waits[0] != rt.backoff.MaxInterval {
+		t.Fatalf("expected a single wait of %s, got %v", rt.backoff.MaxInterval, waits)
+	}
+}
+
+// Record set requests carry their operation's context, so cancelling the
+// operation ends a wait between retries.
+func TestRetryTransportWaitEndsWithContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "20")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	client := testRetryClient(server.URL, 3)
+	client.HTTPClient.Transport.(*retryTransport).sleep = sleepContext
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := getRecordSet(ctx, client, "zone-id", "rs-id"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected the wait to end with the context, took %s", elapsed)
+	}
+}
+
+func TestRetryTransportCreateRequests(t *testing.T) {
+	cases := map[string]struct {
+		Codes       []int
+		ExpectCalls int
+		ExpectCode  int
+	}{
+		"retries throttled creates": {
+			Codes:       []int{http.StatusTooManyRequests, http.StatusTooManyRequests},
+			ExpectCalls: 3,
+		},
+		"does not retry creates that may have been accepted": {
+			Codes:       []int{http.StatusBadGateway},
+			ExpectCalls: 1,
+			ExpectCode:  http.StatusBadGateway,
+		},
+		"does not retry unavailable creates": {
+			Codes:       []int{http.StatusServiceUnavailable},
+			ExpectCalls: 1,
+			ExpectCode:  http.StatusServiceUnavailable,
+		},
+		"does not retry timed out creates": {
+			Codes:       []int{http.StatusGatewayTimeout},
+			ExpectCalls: 1,
+			ExpectCode:  http.StatusGatewayTimeout,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			server := newFlakyServer(t, `{"id":"group-id","name":"group"}`, tc.Codes...)
+			client := testRetryClient(server.URL, 3)
+
+			group, err := client.GroupCreate(&vinyldns.Group{Name: "group"})
+			if tc.ExpectCode != 0 {
+				vErr, ok := err.(*vinyldns.Error)
+				if !ok {
+					t.Fatalf("expected a *vinyldns.Error, got %#v", err)
+				}
+				if vErr.ResponseCode != tc.ExpectCode {
+					t.Fatalf("expected response code %d, got %d", tc.ExpectCode, vErr.ResponseCode)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("did not expect an error but one was raised: %s", err)
+				}
+				if group.ID != "group-id" {
+					t.Fatalf("expected group ID group-id, got %s", group.ID)
+				}
+			}
+
+			if server.Calls() != tc.ExpectCalls {
+				t.Fatalf("expected %d calls, got %d", tc.ExpectCalls, server.Calls())
+			}
+		})
+	}
+}
+
+func TestRetryTransportReplaysBody(t *testing.T) {
+	server := newFlakyServer(t, `{"zone":{"id":"zone-id"},"id":"change-id","status":"Pending"}`, http.StatusServiceUnavailable, http.StatusGatewayTimeout)
+	client := testRetryClient(server.URL, 3)
+
+	_, err := client.ZoneUpdate(&vinyldns.Zone{ID: "zone-id", Name: "ok.", Email: "foo@bar.com"})
+	if err != nil {
+		t.Fatalf("did not expect an error but one was raised: %s", err)
+	}
+
+	if len(server.bodies) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(server.bodies))
+	}
+	for i, b := range server.bodies {
+		if b == "" || b != server.bodies[0] {
+			t.Fatalf("expected request %d to replay body %q, got %q", i, server.bodies[0], b)
+		}
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	readErr := &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}
+
+	cases := map[string]struct {
+		Method   string
+		Code     int
+		Err      error
+		Expected bool
+	}{
+		"GET 200":        {Method: http.MethodGet, Code: http.StatusOK, Expected: false},
+		"GET 404":        {Method: http.MethodGet, Code: http.StatusNotFound, Expected: false},
+		"GET 409":        {Method: http.MethodGet, Code: http.StatusConflict, Expected: false},
+		"GET 429":        {Method: http.MethodGet, Code: http.StatusTooManyRequests, Expected: true},
+		"GET 500":        {Method: http.MethodGet, Code: http.StatusInternalServerError, Expected: false},
+		"GET 502":        {Method: http.MethodGet, Code: http.StatusBadGateway, Expected: true},
+		"PUT 503":        {Method: http.MethodPut, Code: http.StatusServiceUnavailable, Expected: true},
+		"DELETE 504":     {Method: http.MethodDelete, Code: http.StatusGatewayTimeout, Expected: true},
+		"POST 429":       {Method: http.MethodPost, Code: http.StatusTooManyRequests, Expected: true},
+		"POST 503":       {Method: http.MethodPost, Code: http.StatusServiceUnavailable, Expected: false},
+		"GET read error": {Method: http.MethodGet, Err: readErr, Expected: true},
+		"POST dial":      {Method: http.MethodPost, Err: dialErr, Expected: true},
+		"POST read":      {Method: http.MethodPost, Err: readErr, Expected: false},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			var resp *http.Response
+			if tc.Err == nil {
+				resp = &http.Response{StatusCode: tc.Code}
+			}
+
+			if got := retryable(tc.Method, resp, tc.Err); got != tc.Expected {
+				t.Fatalf("expected retryable to be %t, got %t", tc.Expected, got)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	cases := map[string]struct {
+		Header   string
+		Expected time.Duration
+		OK       bool
+	}{
+		"missing":  {Header: "", OK: false},
+		"seconds":  {Header: "7", Expected: 7 * time.Second, OK: true},
+		"past":     {Header: "Mon, 02 Jan 2006 15:04:05 GMT", Expected: 0, OK: true},
+		"nonsense": {Header: "soon", OK: false},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tc.Header != "" {
+				resp.Header.Set("Retry-After", tc.Header)
+			}
+
+			got, ok := retryAfter(resp)
+			if ok != tc.OK || got != tc.Expected {
+				t.Fatalf("expected (%s, %t), got (%s, %t)", tc.Expected, tc.OK, got, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2018 Comcast Cable Communications Management, LLC
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vinyldns
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// transportConfig holds the TLS and proxy settings used to reach the
+// VinylDNS API.
+type transportConfig struct {
+	CAFile             string
+	CAPEM              string
+	ClientCert         string
+	ClientKey          string
+	InsecureSkipVerify bool
+	ProxyURL           string
+}
+
+// transportConfigFromResourceData reads the transport settings from the
+// provider block. The VINYLDNS_CA_FILE environment variable is only used
+// when neither ca_file nor ca_pem is set there.
+func transportConfigFromResourceData(d *schema.ResourceData) transportConfig {
+	caFile := d.Get("ca_file").(string)
+	caPEM := d.Get("ca_pem").(string)
+	if caFile == "" && caPEM == "" {
+		caFile = os.Getenv("VINYLDNS_CA_FILE")
+	}
+
+	return transportConfig{
+		CAFile:             caFile,
+		CAPEM:              caPEM,
+		ClientCert:         d.Get("client_cert").(string),
+		ClientKey:          d.Get("client_key").(string),
+		InsecureSkipVerify: d.Get("insecure_skip_verify").(bool),
+		ProxyURL:           d.Get("proxy_url").(string),
+	}
+}
+
+// newTransport returns an *http.Transport based on http.DefaultTransport
+// with the TLS and proxy settings in c applied.
+func newTransport(c transportConfig) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+
+	if c.CAFile != "" || c.CAPEM != "" {
+		caPEM := []byte(c.CAPEM)
+		if c.CAFile != "" {
+			b, err := os.ReadFile(c.CAFile)
+			if err != nil {
+				return nil, fmt.Errorf("error reading ca_file: %s", err)
+			}
+			caPEM = b
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, errors.New("no PEM encoded certificates found in ca_file or ca_pem")
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if c.ClientCert != "" || c.ClientKey != "" {
+		if c.ClientCert == "" || c.ClientKey == "" {
+			return nil, errors.New("client_cert and client_key must be set together")
+		}
+
+		certPEM, err := pemOrFile(c.ClientCert)
+		if err != nil {
+			return nil, fmt.Errorf("error reading client_cert: %s", err)
+		}
+
+		keyPEM, err := pemOrFile(c.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("error reading client_key: %s", err)
+		}
+
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("error loading client certificate: %s", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	if c.ProxyURL != "" {
+		proxy, err := url.Parse(c.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing proxy_url: %s", err)
+		}
+		if proxy.Scheme == "" || proxy.Host == "" {
+			return nil, fmt.Errorf("proxy_url %q must be an absolute URL such as http://proxy.example.com:3128", c.ProxyURL)
+		}
+
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	return transport, nil
+}
+
+// pemOrFile returns v if it holds PEM encoded data, or otherwise the
+// contents of the file at path v.
+func pemOrFile(v string) ([]byte, error) {
+	if strings.HasPrefix(strings.TrimSpace(v), "-----BEGIN") {
+		return []byte(v), nil
+	}
+
+	return os.ReadFile(v)
+}